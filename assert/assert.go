@@ -15,26 +15,59 @@ type Testing interface {
 	FailNow()
 }
 
+type Options struct {
+	// PathFormatter renders record paths. The global formatter set with
+	// matcher.SetPathFormatter is used when it is nil.
+	PathFormatter matcher.PathFormatter
+}
+
+func WithPathFormatter(f matcher.PathFormatter) func(*Options) {
+	return func(o *Options) {
+		o.PathFormatter = f
+	}
+}
+
 type assertion struct {
-	t      Testing
-	expect any
-	target any
-	r      matcher.Recorder
+	t       Testing
+	expect  any
+	target  any
+	r       matcher.Recorder
+	options Options
 }
 
-func New(t Testing, expect, target any) *assertion {
+func New(t Testing, expect, target any, opts ...func(*Options)) *assertion {
 	tt := &assertion{t: t}
 	tt.expect = expect
 	tt.target = target
+	for _, opt := range opts {
+		opt(&tt.options)
+	}
 
-	r, ok := expect.(matcher.Recorder)
-	if ok {
-		tt.r = r
+	switch m := expect.(type) {
+	case matcher.Explainer:
+		tt.r = explained{title: m.Title(), records: m.Explain(target)}
+	case matcher.Recorder:
+		tt.r = m
 	}
 
 	return tt
 }
 
+// explained is the Recorder of a matcher that keeps no records, holding
+// the records it explains the target with.
+type explained struct {
+	title   string
+	records []matcher.Record
+}
+
+func (e explained) Title() string {
+	return e.title
+}
+
+func (e explained) Records() []matcher.Record {
+	return e.records
+}
+
 func (a assertion) Records() []matcher.Record {
 	if a.r == nil {
 		return []matcher.Record{}
@@ -62,7 +95,7 @@ func (a assertion) Records() []matcher.Record {
 
 func (a assertion) Assert() {
 	if a.r == nil {
-		log.Printf("expect %s but got %s", a.expect, Stringify(a.target))
+		log.Printf("expect %s but got %s", matcher.Describe(a.expect), Stringify(a.target))
 		a.t.FailNow()
 		return
 	}
@@ -80,6 +113,10 @@ func (a assertion) PrintResult() {
 
 	msg := []string{a.r.Title()}
 	for _, r := range a.r.Records() {
+		if a.options.PathFormatter != nil {
+			msg = append(msg, r.Format(a.options.PathFormatter))
+			continue
+		}
 		msg = append(msg, r.Error())
 	}
 
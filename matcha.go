@@ -5,17 +5,21 @@ import (
 	"github.com/version-1/go-matcha/matcher"
 )
 
-func Equal(expect, target any) bool {
-	return matcher.Equal(expect, target)
+func Equal(expect, target any, opts ...func(*matcher.EqualOptions)) bool {
+	return matcher.Equal(expect, target, opts...)
 }
 
-func Test(t assert.Testing, expect any, target any) {
+func EqualLoose(expect, target any) bool {
+	return matcher.EqualLoose(expect, target)
+}
+
+func Test(t assert.Testing, expect any, target any, opts ...func(*assert.Options)) {
 	assertion := Equal(expect, target)
 	if assertion {
 		return
 	}
 
-	res := assert.New(t, expect, target)
+	res := assert.New(t, expect, target, opts...)
 	res.Assert()
 }
 
@@ -26,3 +30,9 @@ func Records(r any) []matcher.Record {
 	}
 	return v.Records()
 }
+
+// Explain returns the records of why target doesn't match expect. Unlike
+// Records it also works for matchers that keep no records, such as BeInt.
+func Explain(expect, target any) []matcher.Record {
+	return matcher.Explain(expect, target)
+}
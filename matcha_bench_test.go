@@ -0,0 +1,125 @@
+package matcha
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/version-1/go-matcha/matcher"
+	"github.com/version-1/go-matcha/matcher/slices"
+)
+
+func benchUser() user {
+	return user{
+		ID:        uuid.New(),
+		GroupID:   uuid.New(),
+		Name:      "John Doe",
+		Age:       25,
+		Status:    "active",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func benchUserMatcher() matcher.Matcher {
+	return matcher.StructOf(matcher.StructMap{
+		"EmbededUser": EmbededUser{},
+		"ID":          matcher.BeUUID(),
+		"GroupID":     matcher.BeUUID(),
+		"Name":        "John Doe",
+		"Age":         matcher.BeInt(),
+		"Status":      "active",
+		"CreatedAt":   matcher.BeTime(),
+		"UpdatedAt":   matcher.BeTime(),
+		"Group":       (*group)(nil),
+		"Posts":       []post(nil),
+	})
+}
+
+func BenchmarkEqual(b *testing.B) {
+	b.Run("primitive", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Equal("abc", "abc")
+		}
+	})
+
+	b.Run("matcher", func(b *testing.B) {
+		m := matcher.BeString()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Equal(m, "abc")
+		}
+	})
+}
+
+func BenchmarkStructOf(b *testing.B) {
+	m := benchUserMatcher()
+	u := benchUser()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Equal(m, u)
+	}
+}
+
+func BenchmarkSliceOf(b *testing.B) {
+	target := make([]int, 100)
+	elements := make([]any, 100)
+	for i := range target {
+		target[i] = i
+		elements[i] = i
+	}
+
+	b.Run("ordered", func(b *testing.B) {
+		m := matcher.SliceOf(elements)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Equal(m, target)
+		}
+	})
+
+	b.Run("unordered", func(b *testing.B) {
+		m := matcher.SliceOf(elements, slices.WithPersistOrder(false))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Equal(m, target)
+		}
+	})
+}
+
+func TestAllocations(t *testing.T) {
+	elements := make([]any, 100)
+	target := make([]int, 100)
+	for i := range target {
+		target[i] = i
+		elements[i] = i
+	}
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		max    float64
+	}{
+		{"Equal with primitive", "abc", "abc", 0},
+		{"Equal with matcher", matcher.BeString(), "abc", 0},
+		{"StructOf", benchUserMatcher(), benchUser(), 2},
+		{"SliceOf", matcher.SliceOf(elements), target, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(100, func() {
+				if !Equal(tt.expect, tt.target) {
+					t.Fatalf("Equal(%v, %v) should return true", tt.expect, tt.target)
+				}
+			})
+
+			if allocs > tt.max {
+				t.Errorf("allocs should be <= %v, got %v", tt.max, allocs)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package matcha
+
+import (
+	"testing"
+
+	"github.com/version-1/go-matcha/matcher"
+	"github.com/version-1/go-matcha/matcher/slices"
+)
+
+const usersCSV = `id,name,email
+1,alice,alice@example.com
+2,bob,bob@example.com
+`
+
+func TestCSVOfEqual(t *testing.T) {
+	header := []string{"id", "name", "email"}
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"match", matcher.CSVOf(header, matcher.SliceOf([]any{
+			matcher.StructOf(matcher.StructMap{"id": "1", "name": "alice", "email": matcher.Email()}),
+			matcher.StructOf(matcher.StructMap{"id": "2", "name": "bob", "email": matcher.Email()}),
+		})), usersCSV, true},
+		{"row maps", matcher.CSVOf(header, []any{
+			map[string]any{"id": "1", "name": "alice", "email": matcher.Email()},
+			map[string]any{"id": "2", "name": "bob", "email": "bob@example.com"},
+		}), []byte(usersCSV), true},
+		{"unordered", matcher.CSVOf(header, matcher.SliceOf([]any{
+			matcher.Row{"id": "2", "name": "bob", "email": "bob@example.com"},
+			matcher.Row{"id": "1", "name": "alice", "email": "alice@example.com"},
+		}, slices.WithPersistOrder(false))), usersCSV, true},
+		{"each row", matcher.CSVOf(nil, matcher.Each(matcher.StructOf(matcher.StructMap{
+			"id":    matcher.RegExp("^[0-9]+$"),
+			"name":  matcher.BeString(),
+			"email": matcher.Email(),
+		}))), usersCSV, true},
+		{"other header", matcher.CSVOf([]string{"id", "name"}, matcher.BeAny()), usersCSV, false},
+		{"duplicate header", matcher.CSVOf(nil, matcher.BeAny()), "id,name,name\n1,alice,bob\n", false},
+		{"expected duplicate header", matcher.CSVOf([]string{"id", "name", "name"}, matcher.BeAny()), "id,name,name\n1,alice,bob\n", false},
+		{"other value", matcher.CSVOf(header, []any{
+			map[string]any{"id": "1", "name": "alice", "email": "alice@example.com"},
+			map[string]any{"id": "2", "name": "carol", "email": "bob@example.com"},
+		}), usersCSV, false},
+		{"fewer rows", matcher.CSVOf(header, []any{
+			map[string]any{"id": "1", "name": "alice", "email": "alice@example.com"},
+		}), usersCSV, false},
+		{"inconsistent fields", matcher.CSVOf(nil, matcher.BeAny()), "a,b\n1\n", false},
+		{"empty", matcher.CSVOf(nil, matcher.BeAny()), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestCSVOfRecords(t *testing.T) {
+	m := matcher.CSVOf([]string{"id", "name", "email"}, []any{
+		map[string]any{"id": "1", "name": "alice", "email": "alice@example.com"},
+		map[string]any{"id": "2", "name": "carol", "email": "bob@example.com"},
+	})
+	if Equal(m, usersCSV) {
+		t.Fatal("Equal should return false")
+	}
+
+	records := Records(m)
+	if len(records) != 1 || len(records[0].Children) != 1 {
+		t.Fatalf("records should have one row with one column, got %v", records)
+	}
+
+	if p := records[0].Children[0].Path(); p != "1 > name" {
+		t.Errorf("Path should be 1 > name, got %s", p)
+	}
+
+	if records[0].Children[0].Code != matcher.RecordCodeNotEqual {
+		t.Errorf("Code should be %s, got %s", matcher.RecordCodeNotEqual, records[0].Children[0].Code)
+	}
+}
+
+func TestCSVOfDuplicateHeader(t *testing.T) {
+	m := matcher.CSVOf(nil, matcher.BeAny())
+	if Equal(m, "id,name,email,name\n1,alice,alice@example.com,bob\n") {
+		t.Fatal("Equal should return false")
+	}
+
+	records := Records(m)
+	if len(records) != 1 || len(records[0].Children) != 1 {
+		t.Fatalf("records should have one header record with one duplicate, got %v", records)
+	}
+
+	r := records[0].Children[0]
+	if r.Code != matcher.RecordCodeDuplicate {
+		t.Errorf("Code should be %s, got %s", matcher.RecordCodeDuplicate, r.Code)
+	}
+
+	if p := r.Path(); p != "header > 3" {
+		t.Errorf("Path should be header > 3, got %s", p)
+	}
+}
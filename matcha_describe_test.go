@@ -0,0 +1,84 @@
+package matcha
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/version-1/go-matcha/matcher"
+	"github.com/version-1/go-matcha/matcher/slices"
+	"github.com/version-1/go-matcha/matcher/structs"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect any
+		ans    string
+	}{
+		{"int", matcher.BeInt(), "a non-zero int"},
+		{"int allow zero", matcher.BeInt().AllowZero(), "an int"},
+		{"string allow named", matcher.BeString().AllowNamed(), "a non-zero string or named string"},
+		{"bool", matcher.BeBool(), "a bool"},
+		{"any", matcher.BeAny(), "any non-zero value"},
+		{"zero", matcher.BeZero(), "a zero value"},
+		{"nil", matcher.BeNil(), "nil"},
+		{"regexp", matcher.RegExp("^a.*$"), "a string matching ^a.*$"},
+		{"not", matcher.Email().Not(), "not an email"},
+		{"pointer", matcher.BeTime().Pointer(), "a pointer to a non-zero time"},
+		{"any of", matcher.AnyOf(matcher.BeInt(), matcher.BeNil()), "a non-zero int or nil"},
+		{"format", matcher.BeIPv4(), "an IPv4 address"},
+		{"uuid", matcher.BeUUID().Version(uuid.Version(4), uuid.Version(7)), "a non-zero UUID of version 4 or 7"},
+		{"ulid", matcher.BeULID(), "a non-zero ULID"},
+		{"type", matcher.BeKind(reflect.Map), "a value of kind map"},
+		{"enum", matcher.Enum("admin", "member"), `one of ["admin", "member"]`},
+		{"time within", matcher.BeTimeWithin(time.Minute), "a time within 1m0s of now"},
+		{"time string", matcher.BeTimeString(matcher.LayoutUnix, nil), "a time string in layout unix"},
+		{"duration", matcher.BeDurationBetween(time.Second, time.Minute), "a duration between 1s and 1m0s"},
+		{"null", matcher.NullOf("Alice"), `a valid null value of "Alice"`},
+		{"slice len", matcher.SliceLen(2), "a slice of length 2"},
+		{"slice of", matcher.SliceOf([]any{1, matcher.BeString()}, slices.WithPersistOrder(false)), "a slice of [1, a non-zero string] in any order"},
+		{"struct of", matcher.StructOf(matcher.StructMap{
+			"Name": "Alice",
+			"Age":  matcher.BeInt(),
+		}, structs.WithContains(true)), `a struct containing {Age: a non-zero int, Name: "Alice"}`},
+		{"each", matcher.Each(matcher.BeInt()), "a slice whose elements are each a non-zero int"},
+		{"last", matcher.Last("a"), `a slice whose last element is "a"`},
+		{"contain in order", matcher.ContainInOrder(1, 2), "a slice containing [1, 2] in order"},
+		{"unique", matcher.HaveUniqueElements(), "a slice without duplicates"},
+		{"xml", matcher.XMLOf(matcher.XMLNode{Name: "order"}), "an XML document with root element <order>"},
+		{"plain value", 1, "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Describe(tt.expect); got != tt.ans {
+				t.Errorf("Describe(%v) should return %q, got %q", tt.expect, tt.ans, got)
+			}
+		})
+	}
+}
+
+func TestDescribeInRecords(t *testing.T) {
+	m := matcher.StructOf(matcher.StructMap{
+		"Name": matcher.BeString(),
+		"Age":  matcher.BeInt(),
+	})
+	if Equal(m, struct {
+		Name string
+		Age  int
+	}{Name: "Alice"}) {
+		t.Fatal("Equal should return false")
+	}
+
+	records := Records(m)
+	if len(records) != 1 {
+		t.Fatalf("Length should be 1, got %d", len(records))
+	}
+
+	if s := records[0].String(); !strings.Contains(s, "expect: a non-zero int") {
+		t.Errorf("String should describe the matcher, got %q", s)
+	}
+}
@@ -0,0 +1,94 @@
+package matcha
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/version-1/go-matcha/internal/pointer"
+	"github.com/version-1/go-matcha/matcher"
+	"github.com/version-1/go-matcha/matcher/structs"
+)
+
+type retryConfig struct {
+	Timeout time.Duration
+	Backoff string
+}
+
+func TestDurationEqual(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"duration", matcher.BeDuration(), time.Second, true},
+		{"duration with zero", matcher.BeDuration(), time.Duration(0), false},
+		{"duration allow zero", matcher.BeDuration().AllowZero(), time.Duration(0), true},
+		{"duration with string", matcher.BeDuration(), "1m30s", true},
+		{"duration with string pointer", matcher.BeDuration(), pointer.Ref("1h"), true},
+		{"duration with named string", matcher.BeDuration(), namedString("500ms"), true},
+		{"duration with invalid string", matcher.BeDuration(), "1 minute", false},
+		{"duration with int64", matcher.BeDuration(), int64(time.Second), false},
+		{"between", matcher.BeDurationBetween(time.Second, time.Minute), 30 * time.Second, true},
+		{"between min", matcher.BeDurationBetween(time.Second, time.Minute), time.Second, true},
+		{"between max", matcher.BeDurationBetween(time.Second, time.Minute), time.Minute, true},
+		{"between below", matcher.BeDurationBetween(time.Second, time.Minute), time.Millisecond, false},
+		{"between with string", matcher.BeDurationBetween(time.Second, time.Minute), "1m30s", false},
+		{"between with zero", matcher.BeDurationBetween(0, time.Minute), time.Duration(0), true},
+		{"approx", matcher.BeDurationApprox(time.Second, 100*time.Millisecond), 950 * time.Millisecond, true},
+		{"approx above", matcher.BeDurationApprox(time.Second, 100*time.Millisecond), "1.2s", false},
+		{"approx below", matcher.BeDurationApprox(time.Second, 100*time.Millisecond), "850ms", false},
+		{"ref", matcher.BeDuration().Pointer(), pointer.Ref(time.Second), true},
+		{"struct of", matcher.StructOf(matcher.StructMap{
+			"Timeout": matcher.BeDurationBetween(time.Second, 10*time.Second),
+			"Backoff": matcher.BeDurationApprox(time.Second, 0),
+		}, structs.WithContains(true)), retryConfig{Timeout: 5 * time.Second, Backoff: "1s"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestDurationRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect matcher.Matcher
+		target any
+		code   matcher.RecordCode
+		ans    string
+	}{
+		{"parse error", matcher.BeDuration(), "1 minute", matcher.RecordCodeParseError, `time: unknown unit " minute"`},
+		{"out of range", matcher.BeDurationBetween(time.Second, time.Minute), time.Hour, matcher.RecordCodeNotEqual, "got: 1h0m0s"},
+		{"zero", matcher.BeDuration(), time.Duration(0), matcher.RecordCodeZeroValue, "Value is zero"},
+		{"zero string", matcher.BeDuration(), "0s", matcher.RecordCodeZeroValue, "Value is zero"},
+		{"out of range string", matcher.BeDurationApprox(time.Minute, time.Second), "2m", matcher.RecordCodeNotEqual, "got: 2m"},
+		{"unexpected type", matcher.BeDuration(), 1, matcher.RecordCodeUnexpectedType, "expect time.Duration but got int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) {
+				t.Fatalf("Equal(%v, %v) should return false", tt.expect, tt.target)
+			}
+
+			records := Explain(tt.expect, tt.target)
+			if len(records) != 1 {
+				t.Fatalf("Length should be 1, got %d", len(records))
+			}
+
+			if records[0].Code != tt.code {
+				t.Errorf("Code should be %s, got %s", tt.code, records[0].Code)
+			}
+
+			if !strings.Contains(records[0].String(), tt.ans) {
+				t.Errorf("String should contain %q, got %q", tt.ans, records[0].String())
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package matcha
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/version-1/go-matcha/matcher"
+	"github.com/version-1/go-matcha/matcher/structs"
+)
+
+func TestElementsEqual(t *testing.T) {
+	uid := uuid.New()
+	byID := func(p post) uuid.UUID { return p.ID }
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		// each
+		{"each: match", matcher.Each(matcher.BeInt()), []any{1, 2, 3}, true},
+		{"each: match empty", matcher.Each(matcher.BeInt()), []int{}, true},
+		{"each: not match", matcher.Each(matcher.BeInt()), []any{1, "2", 3}, false},
+		{"each: value", matcher.Each("a"), []string{"a", "a"}, true},
+		{"each: not slice", matcher.Each(matcher.BeInt()), 1, false},
+		{"each: nil", matcher.Each(matcher.BeInt()), nil, false},
+		// contain element
+		{"contain element: match", matcher.ContainElement(2), []int{1, 2, 3}, true},
+		{"contain element: match matcher", matcher.ContainElement(matcher.BeString()), []any{1, "a"}, true},
+		{"contain element: not match", matcher.ContainElement(4), []int{1, 2, 3}, false},
+		// contain elements
+		{"contain elements: match", matcher.ContainElements(3, 1), []int{1, 2, 3}, true},
+		{"contain elements: distinct elements", matcher.ContainElements(matcher.BeString(), "a"), []string{"a", "b"}, true},
+		{"contain elements: not match", matcher.ContainElements("a", "a"), []string{"a", "b"}, false},
+		// contain in order
+		{"contain in order: match", matcher.ContainInOrder(1, 3, 5), []int{1, 2, 3, 4, 5}, true},
+		{"contain in order: match matcher", matcher.ContainInOrder(matcher.BeInt(), "a"), []any{"x", 1, "y", "a"}, true},
+		{"contain in order: wrong order", matcher.ContainInOrder(3, 1), []int{1, 2, 3}, false},
+		{"contain in order: missing", matcher.ContainInOrder(1, 4), []int{1, 2, 3}, false},
+		// unique
+		{"unique: match", matcher.HaveUniqueElements(), []int{1, 2, 3}, true},
+		{"unique: not match", matcher.HaveUniqueElements(), []int{1, 2, 1}, false},
+		{"unique: not hashable", matcher.HaveUniqueElements(), []any{[]int{1}, []int{1}}, false},
+		{"unique by: match", matcher.HaveUniqueBy(byID), []post{{ID: uuid.New()}, {ID: uuid.New()}}, true},
+		{"unique by: not match", matcher.HaveUniqueBy(byID), []post{{ID: uid, Title: "a"}, {ID: uid, Title: "b"}}, false},
+		{"unique by: unexpected type", matcher.HaveUniqueBy(byID), []int{1}, false},
+		// at
+		{"at: match", matcher.At(1, "b"), []string{"a", "b", "c"}, true},
+		{"at: negative index", matcher.At(-2, "b"), []string{"a", "b", "c"}, true},
+		{"at: out of range", matcher.At(3, "b"), []string{"a", "b", "c"}, false},
+		{"first: match", matcher.First("a"), []string{"a", "b", "c"}, true},
+		{"first: empty", matcher.First("a"), []string{}, false},
+		{"last: match", matcher.Last(matcher.BeString()), []any{1, "c"}, true},
+		{"last: not match", matcher.Last("a"), []string{"a", "b", "c"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestElementsNotMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    []matcher.Record
+	}{
+		{
+			name:   "each reports offending indices",
+			expect: matcher.Each(matcher.BeInt()),
+			target: []any{1, "2", 3, "4"},
+			ans: []matcher.Record{
+				{Key: "1", Code: matcher.RecordCodeNotEqual},
+				{Key: "3", Code: matcher.RecordCodeNotEqual},
+			},
+		},
+		{
+			name:   "unique reports duplicated indices",
+			expect: matcher.HaveUniqueElements(),
+			target: []string{"a", "b", "a", "a"},
+			ans: []matcher.Record{
+				{Key: "2", Code: matcher.RecordCodeDuplicate, Expect: 0},
+				{Key: "3", Code: matcher.RecordCodeDuplicate, Expect: 0},
+			},
+		},
+		{
+			name:   "contain in order reports missing expectation",
+			expect: matcher.ContainInOrder(1, 2, 3),
+			target: []int{3, 2, 1},
+			ans: []matcher.Record{
+				{Key: "1", Code: matcher.RecordCodeNotFound},
+			},
+		},
+		{
+			name:   "at reports resolved index",
+			expect: matcher.Last("a"),
+			target: []string{"a", "b"},
+			ans: []matcher.Record{
+				{Key: "1", Code: matcher.RecordCodeNotEqual},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Equal(tt.expect, tt.target)
+			records := Records(tt.expect)
+			if len(records) != len(tt.ans) {
+				t.Fatalf("Length should be %d, got %d", len(tt.ans), len(records))
+			}
+
+			for i, r := range records {
+				if r.Key != tt.ans[i].Key {
+					t.Errorf("r.Key should be %s, got %s", tt.ans[i].Key, r.Key)
+				}
+
+				if r.Code != tt.ans[i].Code {
+					t.Errorf("r.Code should be %s, got %s", tt.ans[i].Code, r.Code)
+				}
+
+				if tt.ans[i].Expect != nil && r.Expect != tt.ans[i].Expect {
+					t.Errorf("r.Expect should be %v, got %v", tt.ans[i].Expect, r.Expect)
+				}
+			}
+		})
+	}
+}
+
+func TestElementsRecordPath(t *testing.T) {
+	expect := matcher.StructOf(matcher.StructMap{
+		"Posts": matcher.Each(matcher.StructOf(matcher.StructMap{
+			"Title": matcher.BeString(),
+		}, structs.WithContains(true))),
+	}, structs.WithContains(true))
+
+	target := user{
+		Posts: []post{{Title: "a"}, {Title: "b"}, {Title: "c"}, {Title: ""}},
+	}
+
+	if Equal(expect, target) {
+		t.Fatal("Equal should return false")
+	}
+
+	records := Records(expect)
+	if len(records) != 1 || len(records[0].Children) != 1 {
+		t.Fatalf("records should have one child, got %v", records)
+	}
+
+	child := records[0].Children[0]
+	if child.Path() != "Posts > 3" {
+		t.Errorf("Path should be Posts > 3, got %s", child.Path())
+	}
+
+	if len(child.Children) != 1 || child.Children[0].Path() != "Posts > 3 > Title" {
+		t.Errorf("nested path should be Posts > 3 > Title, got %v", child.Children)
+	}
+}
@@ -0,0 +1,129 @@
+package matcha
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/version-1/go-matcha/internal/pointer"
+	"github.com/version-1/go-matcha/matcher"
+	"github.com/version-1/go-matcha/matcher/urls"
+)
+
+func TestFormatEqual(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		// url
+		{"url", matcher.BeURL(), "https://example.com/a?b=c", true},
+		{"url with string pointer", matcher.BeURL(), pointer.Ref("https://example.com"), true},
+		{"url with named string", matcher.BeURL(), namedString("https://example.com"), true},
+		{"url without scheme", matcher.BeURL(), "example.com/a", false},
+		{"url without host", matcher.BeURL(), "https:///a", false},
+		{"url with opaque", matcher.BeURL(), "mailto:john@example.com", true},
+		{"url with allowed scheme", matcher.BeURL(urls.WithSchemes("https")), "HTTPS://example.com", true},
+		{"url with other scheme", matcher.BeURL(urls.WithSchemes("https")), "http://example.com", false},
+		{"url with allowed host", matcher.BeURL(urls.WithHosts("example.com")), "https://example.com:8080", true},
+		{"url with wildcard host", matcher.BeURL(urls.WithHosts("*.example.com")), "https://api.example.com", true},
+		{"url with wildcard parent host", matcher.BeURL(urls.WithHosts("*.example.com")), "https://example.com", false},
+		{"url with int", matcher.BeURL(), 1, false},
+		// ip
+		{"ip v4", matcher.BeIP(), "192.168.0.1", true},
+		{"ip v6", matcher.BeIP(), "::1", true},
+		{"ip invalid", matcher.BeIP(), "256.0.0.1", false},
+		{"ipv4", matcher.BeIPv4(), "10.0.0.1", true},
+		{"ipv4 with v6", matcher.BeIPv4(), "fe80::1", false},
+		{"ipv6", matcher.BeIPv6(), "fe80::1", true},
+		{"ipv6 with v4", matcher.BeIPv6(), "10.0.0.1", false},
+		{"cidr", matcher.BeCIDR(), "10.0.0.0/8", true},
+		{"cidr v6", matcher.BeCIDR(), "2001:db8::/32", true},
+		{"cidr without prefix", matcher.BeCIDR(), "10.0.0.0", false},
+		// hostname
+		{"hostname", matcher.BeHostname(), "api-1.example.com", true},
+		{"hostname with trailing dot", matcher.BeHostname(), "example.com.", true},
+		{"hostname with underscore", matcher.BeHostname(), "a_b.example.com", false},
+		{"hostname with leading hyphen", matcher.BeHostname(), "-a.example.com", false},
+		{"hostname with empty label", matcher.BeHostname(), "a..com", false},
+		{"hostname with long label", matcher.BeHostname(), strings.Repeat("a", 64) + ".com", false},
+		{"empty hostname", matcher.BeHostname(), "", false},
+		// semver
+		{"semver", matcher.BeSemver(), "1.2.3", true},
+		{"semver with pre-release and build", matcher.BeSemver(), "1.0.0-rc.1+build.5", true},
+		{"semver with v prefix", matcher.BeSemver(), "v1.2.3", false},
+		{"semver with leading zero", matcher.BeSemver(), "01.2.3", false},
+		{"semver without patch", matcher.BeSemver(), "1.2", false},
+		// base64 and hex
+		{"base64", matcher.BeBase64(), "aGVsbG8=", true},
+		{"base64 raw url", matcher.BeBase64(), "-_8", true},
+		{"base64 invalid", matcher.BeBase64(), "a!b=", false},
+		{"hex", matcher.BeHex(), "deadBEEF", true},
+		{"hex with odd length", matcher.BeHex(), "abc", false},
+		{"hex invalid", matcher.BeHex(), "zz", false},
+		{"hex empty", matcher.BeHex(), "", false},
+		{"hex empty with allow zero", matcher.BeHex().AllowZero(), "", true},
+		{"base64 empty", matcher.BeBase64(), "", false},
+		{"base64 empty with allow zero", matcher.BeBase64().AllowZero(), "", true},
+		// iso8601
+		{"iso8601 date time", matcher.BeISO8601(), "2024-01-02T03:04:05Z", true},
+		{"iso8601 with offset", matcher.BeISO8601(), "2024-01-02T03:04:05.123+09:00", true},
+		{"iso8601 basic offset", matcher.BeISO8601(), "2024-01-02T03:04:05+0900", true},
+		{"iso8601 date", matcher.BeISO8601(), "2024-01-02", true},
+		{"iso8601 basic", matcher.BeISO8601(), "20240102T030405Z", true},
+		{"iso8601 invalid month", matcher.BeISO8601(), "2024-13-02", false},
+		{"iso8601 other format", matcher.BeISO8601(), "01/02/2024", false},
+		// mac
+		{"mac", matcher.BeMACAddress(), "00:1a:2b:3c:4d:5e", true},
+		{"mac with hyphens", matcher.BeMACAddress(), "00-1A-2B-3C-4D-5E", true},
+		{"mac invalid", matcher.BeMACAddress(), "00:1a:2b", false},
+		// wrappers
+		{"ref", matcher.BeIP().Pointer(), pointer.Ref("::1"), true},
+		{"not", matcher.BeIP().Not(), "localhost", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestFormatRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect matcher.Matcher
+		target any
+		code   matcher.RecordCode
+		ans    string
+	}{
+		{"parse error", matcher.BeIP(), "1.2.3", matcher.RecordCodeParseError, `Value is not a valid ip. ParseAddr("1.2.3"): IPv4 address too short`},
+		{"constraint", matcher.BeURL(urls.WithSchemes("https")), "http://a.com", matcher.RecordCodeParseError, `scheme "http" is not one of [https]`},
+		{"hostname", matcher.BeHostname(), "a_b", matcher.RecordCodeParseError, `label "a_b" has invalid character '_'`},
+		{"unexpected type", matcher.BeHex(), 1, matcher.RecordCodeUnexpectedType, "expect string but got int"},
+		{"empty", matcher.BeBase64(), "", matcher.RecordCodeZeroValue, "Value is zero."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) {
+				t.Fatalf("Equal(%v, %v) should return false", tt.expect, tt.target)
+			}
+
+			records := Explain(tt.expect, tt.target)
+			if len(records) != 1 {
+				t.Fatalf("Length should be 1, got %d", len(records))
+			}
+
+			if records[0].Code != tt.code {
+				t.Errorf("Code should be %s, got %s", tt.code, records[0].Code)
+			}
+
+			if !strings.Contains(records[0].String(), tt.ans) {
+				t.Errorf("String should contain %q, got %q", tt.ans, records[0].String())
+			}
+		})
+	}
+}
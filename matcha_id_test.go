@@ -0,0 +1,155 @@
+package matcha
+
+import (
+	"encoding/binary"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/version-1/go-matcha/internal/pointer"
+	"github.com/version-1/go-matcha/matcher"
+)
+
+// newULID encodes a ULID with timestamp t and zero entropy.
+func newULID(t time.Time) string {
+	const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+	ms := uint64(t.UnixMilli())
+	b := make([]byte, 26)
+	for i := 9; i >= 0; i-- {
+		b[i] = crockford[ms&31]
+		ms >>= 5
+	}
+	for i := 10; i < 26; i++ {
+		b[i] = '0'
+	}
+
+	return string(b)
+}
+
+func newSnowflake(t, epoch time.Time) int64 {
+	return t.Sub(epoch).Milliseconds()<<22 | 1234
+}
+
+// textULID mirrors ulid.ULID: 16 raw bytes that marshal to their
+// Crockford base32 text.
+type textULID [16]byte
+
+func (u textULID) MarshalText() ([]byte, error) {
+	const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+	n := new(big.Int).SetBytes(u[:])
+	b := make([]byte, 26)
+	for i := 25; i >= 0; i-- {
+		b[i] = crockford[new(big.Int).And(n, big.NewInt(31)).Int64()]
+		n.Rsh(n, 5)
+	}
+
+	return b, nil
+}
+
+// FooULID is a plain byte array whose name merely ends in ULID.
+type FooULID [16]byte
+
+func TestULIDEqual(t *testing.T) {
+	created := time.UnixMilli(1469922850259)
+	var raw textULID
+	binary.BigEndian.PutUint16(raw[:2], uint16(created.UnixMilli()>>32))
+	binary.BigEndian.PutUint32(raw[2:6], uint32(created.UnixMilli()))
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"text", matcher.BeULID(), "01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{"lower case text", matcher.BeULID(), "01arz3ndektsv4rrffq69g5fav", true},
+		{"string pointer", matcher.BeULID(), pointer.Ref("01ARZ3NDEKTSV4RRFFQ69G5FAV"), true},
+		{"text bytes", matcher.BeULID(), []byte("01ARZ3NDEKTSV4RRFFQ69G5FAV"), true},
+		{"raw bytes", matcher.BeULID(), raw[:], true},
+		{"text marshaler", matcher.BeULID(), raw, true},
+		{"byte array named like a ULID", matcher.BeULID(), FooULID(raw), false},
+		{"too short", matcher.BeULID(), "01ARZ3NDEKTSV4RRFFQ69G5FA", false},
+		{"overflow", matcher.BeULID(), "81ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+		{"invalid character", matcher.BeULID(), "01ARZ3NDEKTSV4RRFFQ69G5FAU", false},
+		{"empty", matcher.BeULID(), "", false},
+		{"int", matcher.BeULID(), 1, false},
+		{"uuid", matcher.BeULID(), uuid.New(), false},
+		{"timestamp", matcher.BeULID().Timestamp(created), "01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{"text marshaler timestamp", matcher.BeULID().Timestamp(created), raw, true},
+		{"timestamp within", matcher.BeULID().Timestamp(matcher.BeTimeWithin(time.Minute)), newULID(time.Now()), true},
+		{"old timestamp within", matcher.BeULID().Timestamp(matcher.BeTimeWithin(time.Minute)), "01ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestKSUIDEqual(t *testing.T) {
+	created := time.Date(2017, 10, 10, 4, 0, 47, 0, time.UTC)
+	raw := make([]byte, 20)
+	binary.BigEndian.PutUint32(raw, uint32(created.Unix()-1400000000))
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"text", matcher.BeKSUID(), "0ujtsYcgvSTl8PAuAdqWYSMnLOv", true},
+		{"max", matcher.BeKSUID(), "aWgEPTl1tmebfsQzFP4bxwgy80V", true},
+		{"overflow", matcher.BeKSUID(), "aWgEPTl1tmebfsQzFP4bxwgy80W", false},
+		{"invalid character", matcher.BeKSUID(), "0ujtsYcgvSTl8PAuAdqWYSMnLO-", false},
+		{"too long", matcher.BeKSUID(), "0ujtsYcgvSTl8PAuAdqWYSMnLOv0", false},
+		{"raw bytes", matcher.BeKSUID(), raw, true},
+		{"timestamp", matcher.BeKSUID().Timestamp(matcher.BeTimeAfter(created.Add(-time.Second))), "0ujtsYcgvSTl8PAuAdqWYSMnLOv", true},
+		{"timestamp equal", matcher.BeKSUID().Timestamp(created.Local()), raw, true},
+		{"timestamp before", matcher.BeKSUID().Timestamp(matcher.BeTimeBefore(created)), "0ujtsYcgvSTl8PAuAdqWYSMnLOv", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestSnowflakeEqual(t *testing.T) {
+	now := time.Now()
+	id := newSnowflake(now, matcher.TwitterSnowflakeEpoch)
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"int64", matcher.BeSnowflake(matcher.TwitterSnowflakeEpoch), id, true},
+		{"uint64", matcher.BeSnowflake(matcher.TwitterSnowflakeEpoch), uint64(id), true},
+		{"string", matcher.BeSnowflake(matcher.TwitterSnowflakeEpoch), strconv.FormatInt(id, 10), true},
+		{"zero", matcher.BeSnowflake(matcher.TwitterSnowflakeEpoch), int64(0), false},
+		{"negative", matcher.BeSnowflake(matcher.TwitterSnowflakeEpoch), int64(-1), false},
+		{"not a number", matcher.BeSnowflake(matcher.TwitterSnowflakeEpoch), "12a", false},
+		{"timestamp within", matcher.BeSnowflake(matcher.TwitterSnowflakeEpoch).Timestamp(matcher.BeTimeWithin(time.Minute)), id, true},
+		{"timestamp with other epoch", matcher.BeSnowflake(matcher.DiscordSnowflakeEpoch).Timestamp(matcher.BeTimeWithin(time.Minute)), id, false},
+		{"ref", matcher.BeSnowflake(matcher.TwitterSnowflakeEpoch).Pointer(), &id, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package matcha
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/version-1/go-matcha/assert"
+	"github.com/version-1/go-matcha/internal/pointer"
+	"github.com/version-1/go-matcha/matcher"
+)
+
+type jsonPost struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+}
+
+type jsonUser struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	Age       int64      `json:"age"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"createdAt"`
+	Group     *group     `json:"group"`
+	Posts     []jsonPost `json:"posts"`
+	Secret    string     `json:"-"`
+}
+
+func TestLoadJSONExpectation(t *testing.T) {
+	valid := jsonUser{
+		ID:        uuid.New(),
+		Name:      "John Doe",
+		Email:     "john@example.com",
+		Age:       25,
+		Status:    "active",
+		CreatedAt: time.Now(),
+		Posts: []jsonPost{
+			{ID: uuid.New(), Title: "first", Description: pointer.Ref("hello")},
+			{ID: uuid.New(), Title: "second"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		target func() any
+		ans    bool
+	}{
+		{"struct target: match", func() any { return valid }, true},
+		{"pointer struct target: match", func() any { return &valid }, true},
+		{"struct target: not match uuid", func() any {
+			u := valid
+			u.ID = uuid.Nil
+			return u
+		}, false},
+		{"struct target: not match regexp", func() any {
+			u := valid
+			u.Status = "deleted"
+			return u
+		}, false},
+		{"struct target: not match null", func() any {
+			u := valid
+			u.Group = &group{}
+			return u
+		}, false},
+		{"struct target: not match array length", func() any {
+			u := valid
+			u.Posts = u.Posts[:1]
+			return u
+		}, false},
+		{"map target: match", func() any {
+			return map[string]any{
+				"id":        uuid.New(),
+				"name":      "John Doe",
+				"email":     "john@example.com",
+				"age":       25,
+				"status":    "inactive",
+				"createdAt": time.Now(),
+				"group":     nil,
+				"posts": []any{
+					map[string]any{"id": uuid.New(), "title": "first", "description": "hello"},
+					map[string]any{"id": uuid.New(), "title": "second", "description": nil},
+				},
+			}
+		}, true},
+		{"map target: not match missing key", func() any {
+			return map[string]any{"id": uuid.New()}
+		}, false},
+		{"not object target", func() any { return 1 }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := matcher.LoadJSONExpectation("testdata/user.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if Equal(m, tt.target()) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", m, tt.target(), tt.ans)
+			}
+		})
+	}
+}
+
+func TestJSONExpectationNotMatch(t *testing.T) {
+	m, err := matcher.ParseJSONExpectation([]byte(`{"id": "<<uuid>>", "posts": [{"title": "first"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := jsonUser{Posts: []jsonPost{{Title: "second"}}}
+	Equal(m, target)
+	records := assert.New(t, m, target).Records()
+
+	ans := []matcher.Record{
+		{
+			Key:  "id",
+			Code: matcher.RecordCodeNotEqual,
+			Children: []matcher.Record{
+				{Code: matcher.RecordCodeZeroValue},
+			},
+		},
+		{
+			Key:  "posts",
+			Code: matcher.RecordCodeNotEqual,
+			Children: []matcher.Record{
+				{
+					Key:  "0",
+					Code: matcher.RecordCodeNotEqual,
+					Children: []matcher.Record{
+						{
+							Key:  "title",
+							Code: matcher.RecordCodeNotEqual,
+							Children: []matcher.Record{
+								{Code: matcher.RecordCodeNotEqual},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if len(records) != len(ans) {
+		t.Fatalf("Length should be %d, got %d", len(ans), len(records))
+	}
+
+	var check func(got, want []matcher.Record)
+	check = func(got, want []matcher.Record) {
+		for i, r := range got {
+			if r.Key != want[i].Key {
+				t.Errorf("r.Key should be %s, got %s", want[i].Key, r.Key)
+			}
+
+			if r.Code != want[i].Code {
+				t.Errorf("r.Code should be %s, got %s", want[i].Code, r.Code)
+			}
+
+			check(r.Children, want[i].Children)
+		}
+	}
+	check(records, ans)
+
+	if seg := records[0].Segment; seg.Name != "ID" || seg.JSONName != "id" {
+		t.Errorf("Segment should keep the Go name ID and the JSON name id, got %+v", seg)
+	}
+}
+
+func TestJSONPlaceholders(t *testing.T) {
+	matcher.RegisterPlaceholder("even", func(arg string) (matcher.Matcher, error) {
+		return matcher.BeInt(), nil
+	})
+
+	tests := []struct {
+		name   string
+		json   string
+		target any
+		ans    bool
+		err    bool
+	}{
+		{"custom placeholder: match", `{"n": "<<even>>"}`, map[string]any{"n": 2}, true, false},
+		{"custom placeholder: not match", `{"n": "<<even>>"}`, map[string]any{"n": "2"}, false, false},
+		{"regexp placeholder with argument", `"<<regexp:^foo>>"`, "foobar", true, false},
+		{"invalid regexp placeholder", `"<<regexp:(>>"`, nil, false, true},
+		{"unknown placeholder", `"<<unknown>>"`, nil, false, true},
+		{"unknown placeholder in an object", `{"id": "<<uid>>"}`, nil, false, true},
+		{"number literal matches decoded json", `[1, 2.5]`, []any{json.Number("1"), float64(2.5)}, true, false},
+		{"invalid json", `{`, nil, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("Equal: %s", tt.name), func(t *testing.T) {
+			m, err := matcher.ParseJSONExpectation([]byte(tt.json))
+			if (err != nil) != tt.err {
+				t.Fatalf("err should be %v, got %v", tt.err, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if Equal(m, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.json, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+type jsonAudit struct {
+	CreatedBy string `json:"createdBy"`
+}
+
+type jsonDocument struct {
+	*jsonAudit
+	Title string `json:"title"`
+}
+
+func TestJSONNilTargets(t *testing.T) {
+	tests := []struct {
+		name   string
+		json   string
+		target any
+		code   matcher.RecordCode
+		key    string
+	}{
+		{"nil struct pointer", `{"title": "a"}`, (*jsonDocument)(nil), matcher.RecordCodeTargetIsNil, ""},
+		{"nil slice pointer", `["a"]`, (*[]string)(nil), matcher.RecordCodeTargetIsNil, ""},
+		{"field of nil embedded pointer", `{"createdBy": "alice", "title": "a"}`, jsonDocument{Title: "a"}, matcher.RecordCodeNotFound, "createdBy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := matcher.ParseJSONExpectation([]byte(tt.json))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if Equal(m, tt.target) {
+				t.Fatalf("Equal(%v, %v) should return false", tt.json, tt.target)
+			}
+
+			records := Records(m)
+			if len(records) != 1 || records[0].Code != tt.code || records[0].Key != tt.key {
+				t.Errorf("records should be one %s record of %q, got %v", tt.code, tt.key, records)
+			}
+		})
+	}
+
+	m, err := matcher.ParseJSONExpectation([]byte(`{"createdBy": "alice", "title": "a"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := jsonDocument{jsonAudit: &jsonAudit{CreatedBy: "alice"}, Title: "a"}
+	if !Equal(m, target) {
+		t.Errorf("Equal(%v, %v) should return true", m, target)
+	}
+}
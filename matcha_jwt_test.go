@@ -0,0 +1,205 @@
+package matcha
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/version-1/go-matcha/matcher"
+	"github.com/version-1/go-matcha/matcher/jwts"
+)
+
+func signJWT(t *testing.T, alg string, key any, claims map[string]any) string {
+	t.Helper()
+
+	enc := func(v any) string {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	input := enc(map[string]any{"alg": alg, "typ": "JWT"}) + "." + enc(claims)
+	hash := sha256.Sum256([]byte(input))
+
+	var sig []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(input))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		s, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = s
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestJWTEqual(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := []byte("secret")
+
+	now := time.Now()
+	claims := map[string]any{
+		"sub":   uuid.NewString(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"iat":   now.Unix(),
+		"roles": []string{"admin"},
+		"org":   map[string]any{"id": 3, "name": "acme"},
+	}
+	hs := signJWT(t, "HS256", secret, claims)
+	rs := signJWT(t, "RS256", rsaKey, claims)
+	es := signJWT(t, "ES256", ecKey, claims)
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"claims", matcher.JWT(map[string]any{
+			"sub":   matcher.BeUUIDString(),
+			"exp":   matcher.BeTimeAfter(now),
+			"iat":   matcher.BeTimeWithin(time.Minute),
+			"roles": []string{"admin"},
+			"org":   map[string]any{"id": 3},
+		}), hs, true},
+		{"claims not match", matcher.JWT(map[string]any{"roles": []string{"user"}}), hs, false},
+		{"nested claims not match", matcher.JWT(map[string]any{"org": map[string]any{"id": 4}}), hs, false},
+		{"nested claim not an object", matcher.JWT(map[string]any{"sub": map[string]any{"id": 3}}), hs, false},
+		{"missing claim", matcher.JWT(map[string]any{"aud": matcher.BeAny()}), hs, false},
+		{"expired", matcher.JWT(map[string]any{"exp": matcher.BeTimeBefore(now)}), hs, false},
+		{"claims matcher", matcher.JWT(matcher.BeAny()), hs, true},
+		{"header", matcher.JWT(map[string]any{}, jwts.WithHeader(map[string]any{"alg": "HS256"})), hs, true},
+		{"header not match", matcher.JWT(map[string]any{}, jwts.WithHeader(map[string]any{"alg": "RS256"})), hs, false},
+		{"hs256", matcher.JWT(map[string]any{}, jwts.WithKey(secret)), hs, true},
+		{"hs256 with other secret", matcher.JWT(map[string]any{}, jwts.WithKey([]byte("other"))), hs, false},
+		{"rs256", matcher.JWT(map[string]any{}, jwts.WithKey(&rsaKey.PublicKey)), rs, true},
+		{"rs256 with private key", matcher.JWT(map[string]any{}, jwts.WithKey(rsaKey)), rs, true},
+		{"es256", matcher.JWT(map[string]any{}, jwts.WithKey(&ecKey.PublicKey)), es, true},
+		{"key for other alg", matcher.JWT(map[string]any{}, jwts.WithKey(secret)), rs, false},
+		{"tampered claims", matcher.JWT(map[string]any{}, jwts.WithKey(secret)), strings.Replace(hs, ".", ".e30", 1), false},
+		{"two parts", matcher.JWT(map[string]any{}), "a.b", false},
+		{"invalid base64", matcher.JWT(map[string]any{}), "a.!.c", false},
+		{"not a string", matcher.JWT(map[string]any{}), 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestJWTRecords(t *testing.T) {
+	secret := []byte("secret")
+	token := signJWT(t, "HS256", secret, map[string]any{"sub": "john", "admin": false, "org": map[string]any{"id": 3, "name": "acme"}})
+
+	tests := []struct {
+		name   string
+		expect matcher.Matcher
+		target any
+		ans    []matcher.Record
+	}{
+		{
+			"claims",
+			matcher.JWT(map[string]any{"sub": "jane", "admin": false, "aud": "api"}),
+			token,
+			[]matcher.Record{
+				{Key: "aud", Code: matcher.RecordCodeNotFound},
+				{Key: "sub", Code: matcher.RecordCodeNotEqual},
+			},
+		},
+		{
+			"nested claims",
+			matcher.JWT(map[string]any{"org": map[string]any{"id": 4, "plan": "pro"}}),
+			token,
+			[]matcher.Record{
+				{Key: "org", Code: matcher.RecordCodeNotEqual, Children: []matcher.Record{
+					{Key: "id", Code: matcher.RecordCodeNotEqual},
+					{Key: "plan", Code: matcher.RecordCodeNotFound},
+				}},
+			},
+		},
+		{
+			"header",
+			matcher.JWT(map[string]any{}, jwts.WithHeader(map[string]any{"typ": "JWT", "alg": "ES256"})),
+			token,
+			[]matcher.Record{
+				{Key: "Header", Code: matcher.RecordCodeNotEqual, Children: []matcher.Record{
+					{Key: "alg", Code: matcher.RecordCodeNotEqual},
+				}},
+			},
+		},
+		{
+			"signature",
+			matcher.JWT(map[string]any{}, jwts.WithKey([]byte("other"))),
+			token,
+			[]matcher.Record{{Code: matcher.RecordCodeInvalidSignature}},
+		},
+		{
+			"parse error",
+			matcher.JWT(map[string]any{}),
+			"a.b",
+			[]matcher.Record{{Code: matcher.RecordCodeParseError}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) {
+				t.Fatalf("Equal(%v, %v) should return false", tt.expect, tt.target)
+			}
+
+			var check func(got, want []matcher.Record)
+			check = func(got, want []matcher.Record) {
+				if len(got) != len(want) {
+					t.Fatalf("Length should be %d, got %d", len(want), len(got))
+				}
+
+				for i, r := range got {
+					if r.Key != want[i].Key {
+						t.Errorf("r.Key should be %s, got %s", want[i].Key, r.Key)
+					}
+
+					if r.Code != want[i].Code {
+						t.Errorf("r.Code should be %s, got %s", want[i].Code, r.Code)
+					}
+
+					check(r.Children, want[i].Children)
+				}
+			}
+			check(Records(tt.expect), tt.ans)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package matcha
+
+import (
+	"math"
+	"testing"
+
+	"github.com/version-1/go-matcha/internal/pointer"
+	"github.com/version-1/go-matcha/matcher"
+	"github.com/version-1/go-matcha/matcher/slices"
+	"github.com/version-1/go-matcha/matcher/structs"
+)
+
+type looseStatus string
+
+type looseID int64
+
+type looseWorker struct {
+	ID   int
+	jobs chan int
+	run  func()
+}
+
+type looseUser struct {
+	ID     looseID
+	Status looseStatus
+	Score  float32
+	Tags   []string
+	Meta   map[string]any
+}
+
+func TestEqualLoose(t *testing.T) {
+	worker := looseWorker{ID: 1, jobs: make(chan int), run: func() {}}
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		// numbers
+		{"int and int64", 1, int64(1), true},
+		{"int and uint8", 255, uint8(255), true},
+		{"negative int and uint", -1, uint64(math.MaxUint64), false},
+		{"max uint and int64", uint64(math.MaxUint64), int64(-1), false},
+		{"int and integral float", 2, 2.0, true},
+		{"int and fractional float", 1, 1.5, false},
+		{"float and overflowing int", float64(math.MaxInt64), int64(math.MaxInt64), false},
+		{"float32 and float64", float32(0.5), 0.5, true},
+		{"nan", math.NaN(), math.NaN(), false},
+		{"number and string", 1, "1", false},
+		// named types
+		{"string and named string", "active", looseStatus("active"), true},
+		{"named string and string", looseStatus("active"), "inactive", false},
+		{"int and named int", 1, looseID(1), true},
+		// bytes
+		{"string and bytes", "abc", []byte("abc"), true},
+		{"bytes and named string", []byte("abc"), looseStatus("abc"), true},
+		{"string and other bytes", "abc", []byte("abd"), false},
+		// containers
+		{"slices", []int{1, 2}, []int64{1, 2}, true},
+		{"slices with different length", []int{1, 2}, []int64{1}, false},
+		{"slice with matcher", []any{matcher.BeString(), 1}, []any{"a", uint(1)}, true},
+		{"maps", map[string]any{"a": 1}, map[looseStatus]int64{"a": 1}, true},
+		{"maps with different value", map[string]any{"a": 1}, map[string]any{"a": 2}, false},
+		{"maps with missing key", map[string]any{"a": 1}, map[string]any{"b": 1}, false},
+		{"pointers", pointer.Ref(1), pointer.Ref(int64(1)), true},
+		{"nil", nil, nil, true},
+		{"nil and value", nil, 0, false},
+		{"structs", looseUser{ID: 1, Tags: []string{"a"}}, looseUser{ID: 1, Tags: []string{"a"}}, true},
+		{"structs with different field", looseUser{ID: 1}, looseUser{ID: 2}, false},
+		{"structs with unexported chan and func", worker, worker, true},
+		{"structs with other chan", worker, looseWorker{ID: 1, jobs: make(chan int), run: worker.run}, false},
+		{"structs with nil func", looseWorker{ID: 1}, looseWorker{ID: 1}, true},
+		// matchers
+		{"struct of", matcher.StructOf(matcher.StructMap{
+			"ID":     1,
+			"Status": "active",
+			"Score":  1,
+			"Tags":   [][]byte{[]byte("a")},
+			"Meta":   map[string]any{"n": 1},
+		}), looseUser{ID: 1, Status: "active", Score: 1, Tags: []string{"a"}, Meta: map[string]any{"n": int64(1)}}, true},
+		{"struct of with different value", matcher.StructOf(matcher.StructMap{
+			"ID": 2,
+		}, structs.WithContains(true)), looseUser{ID: 1}, false},
+		{"nested slice of", matcher.SliceOf([]any{
+			matcher.StructOf(matcher.StructMap{"ID": 1}, structs.WithContains(true)),
+		}).Pointer(), &[]looseUser{{ID: 1}}, true},
+		{"not", matcher.Not(matcher.SliceOf([]any{1})), []int64{1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if EqualLoose(tt.expect, tt.target) != tt.ans {
+				t.Errorf("EqualLoose(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestWithCoercion(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"struct of without coercion", matcher.StructOf(matcher.StructMap{
+			"ID": 1,
+		}, structs.WithContains(true)), looseUser{ID: 1}, false},
+		{"struct of with coercion", matcher.StructOf(matcher.StructMap{
+			"ID": 1,
+		}, structs.WithContains(true), structs.WithCoercion()), looseUser{ID: 1}, true},
+		{"slice of without coercion", matcher.SliceOf([]any{1, 2}), []int64{1, 2}, false},
+		{"slice of with coercion", matcher.SliceOf([]any{1, 2}, slices.WithCoercion()), []int64{1, 2}, true},
+		{"unordered slice of with coercion", matcher.SliceOf([]any{"b", "a"}, slices.WithPersistOrder(false), slices.WithCoercion()), []looseStatus{"a", "b"}, true},
+		{"slice of with coercion and length mismatch", matcher.SliceOf([]any{1, 2}, slices.WithCoercion()), []int64{1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package matcha
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/version-1/go-matcha/internal/pointer"
+	"github.com/version-1/go-matcha/matcher"
+)
+
+type namedRole int
+
+type namedFlag bool
+
+const (
+	roleAdmin namedRole = iota + 1
+	roleMember
+	roleGuest
+)
+
+func TestAllowNamedEqual(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"string with named string", matcher.BeString(), namedString("a"), false},
+		{"allow named string with named string", matcher.BeString().AllowNamed(), namedString("a"), true},
+		{"allow named string with string", matcher.BeString().AllowNamed(), "a", true},
+		{"allow named string with zero", matcher.BeString().AllowNamed(), namedString(""), false},
+		{"allow named string with named int", matcher.BeString().AllowNamed(), roleAdmin, false},
+		{"allow named string ref", matcher.BeString().AllowNamed().Pointer(), pointer.Ref(namedString("a")), true},
+		{"int with named int", matcher.BeInt(), roleAdmin, false},
+		{"allow named int with named int", matcher.BeInt().AllowNamed(), roleAdmin, true},
+		{"allow named int with int64", matcher.BeInt().AllowNamed(), int64(1), false},
+		{"bool with named bool", matcher.BeBool(), namedFlag(true), false},
+		{"allow named bool with named bool", matcher.BeBool().AllowNamed(), namedFlag(false), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestSetAllowNamed(t *testing.T) {
+	prev := matcher.SetAllowNamed(true)
+	defer matcher.SetAllowNamed(prev)
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"string with named string", matcher.BeString(), namedString("a"), true},
+		{"int with named int", matcher.BeInt(), roleAdmin, true},
+		{"bool with named bool", matcher.BeBool(), namedFlag(true), true},
+		{"int with named string", matcher.BeInt(), namedString("a"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestEnumEqual(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"listed value", matcher.Enum(roleAdmin, roleMember), roleMember, true},
+		{"unlisted value", matcher.Enum(roleAdmin, roleMember), roleGuest, false},
+		{"underlying type", matcher.Enum(roleAdmin, roleMember), 1, false},
+		{"ref", matcher.Enum(roleAdmin).Pointer(), pointer.Ref(roleAdmin), true},
+		{"not", matcher.Enum(roleAdmin).Not(), roleGuest, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestEnumRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		target any
+		code   matcher.RecordCode
+		ans    string
+	}{
+		{"unlisted value", roleGuest, matcher.RecordCodeNotAllowed, "expect one of [1 2] but got 3"},
+		{"unexpected type", 1, matcher.RecordCodeUnexpectedType, "expect github.com/version-1/go-matcha.namedRole but got int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := matcher.Enum(roleAdmin, roleMember)
+			if Equal(m, tt.target) {
+				t.Fatalf("Equal(%v, %v) should return false", m, tt.target)
+			}
+
+			records := Records(m)
+			if len(records) != 1 {
+				t.Fatalf("Length should be 1, got %d", len(records))
+			}
+
+			if records[0].Code != tt.code {
+				t.Errorf("Code should be %s, got %s", tt.code, records[0].Code)
+			}
+
+			if !strings.Contains(records[0].String(), tt.ans) {
+				t.Errorf("String should contain %q, got %q", tt.ans, records[0].String())
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package matcha
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/version-1/go-matcha/matcher"
+	"github.com/version-1/go-matcha/matcher/slices"
+	"github.com/version-1/go-matcha/matcher/structs"
+)
+
+type nullUser struct {
+	ID        uuid.UUID
+	Name      sql.NullString
+	Age       sql.NullInt64
+	DeletedAt sql.NullTime
+	Role      sql.Null[namedRole]
+}
+
+func TestNullEqual(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"null of string", matcher.NullOf("Alice"), sql.NullString{String: "Alice", Valid: true}, true},
+		{"null of other string", matcher.NullOf("Alice"), sql.NullString{String: "Bob", Valid: true}, false},
+		{"null of invalid string", matcher.NullOf(""), sql.NullString{}, false},
+		{"null of int64", matcher.NullOf(int64(3)), sql.NullInt64{Int64: 3, Valid: true}, true},
+		{"null of int", matcher.NullOf(42), sql.NullInt64{Int64: 42, Valid: true}, true},
+		{"null of other int", matcher.NullOf(42), sql.NullInt64{Int64: 43, Valid: true}, false},
+		{"null of float", matcher.NullOf(1.5), sql.NullFloat64{Float64: 1.5, Valid: true}, true},
+		{"null of int with int32", matcher.NullOf(7), sql.NullInt32{Int32: 7, Valid: true}, true},
+		{"parsed null of int", matcher.MustParse("null(42)"), sql.NullInt64{Int64: 42, Valid: true}, true},
+		{"null of matcher", matcher.NullOf(matcher.BeTimeWithin(time.Minute)), sql.NullTime{Time: now, Valid: true}, true},
+		{"null of generic", matcher.NullOf(roleAdmin), sql.Null[namedRole]{V: roleAdmin, Valid: true}, true},
+		{"null of not a null type", matcher.NullOf("Alice"), "Alice", false},
+		{"null of valuer", matcher.NullOf(matcher.BeString()), uuid.New(), true},
+		{"be null", matcher.BeNull(), sql.NullString{}, true},
+		{"be null with valid", matcher.BeNull(), sql.NullString{Valid: true}, false},
+		{"be null with nil", matcher.BeNull(), nil, false},
+		{"be null with nil pointer", matcher.BeNull(), (*sql.NullString)(nil), true},
+		{"null of nil pointer", matcher.NullOf("Alice"), (*sql.NullString)(nil), false},
+		{"be null with nil pointer of other type", matcher.BeNull(), (*int)(nil), false},
+		{"not null", matcher.BeNull().Not(), sql.NullInt64{Valid: true}, true},
+		{"null ref", matcher.NullOf("Alice").Pointer(), &sql.NullString{String: "Alice", Valid: true}, true},
+		{"struct of", matcher.StructOf(matcher.StructMap{
+			"Name":      matcher.NullOf("Alice"),
+			"DeletedAt": matcher.BeNull(),
+		}, structs.WithContains(true)), nullUser{Name: sql.NullString{String: "Alice", Valid: true}}, true},
+		{"equal without unwrapping", "Alice", sql.NullString{String: "Alice", Valid: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestWithUnwrapValuers(t *testing.T) {
+	id := uuid.New()
+	alice := sql.NullString{String: "Alice", Valid: true}
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"string", matcher.StructOf(matcher.StructMap{"Name": "Alice"}, structs.WithContains(true), structs.WithUnwrapValuers()), nullUser{Name: alice}, true},
+		{"other string", matcher.StructOf(matcher.StructMap{"Name": "Bob"}, structs.WithContains(true), structs.WithUnwrapValuers()), nullUser{Name: alice}, false},
+		{"nil", matcher.StructOf(matcher.StructMap{"Name": nil}, structs.WithContains(true), structs.WithUnwrapValuers()), nullUser{}, true},
+		{"nil with valid", matcher.StructOf(matcher.StructMap{"Name": nil}, structs.WithContains(true), structs.WithUnwrapValuers()), nullUser{Name: alice}, false},
+		{"same null type", matcher.StructOf(matcher.StructMap{"Name": alice}, structs.WithContains(true), structs.WithUnwrapValuers()), nullUser{Name: alice}, true},
+		{"valuer expectation", matcher.StructOf(matcher.StructMap{"ID": id}, structs.WithContains(true), structs.WithUnwrapValuers()), nullUser{ID: id}, true},
+		{"matcher gets field as is", matcher.StructOf(matcher.StructMap{"Name": matcher.BeString()}, structs.WithContains(true), structs.WithUnwrapValuers()), nullUser{Name: alice}, false},
+		{"without option", matcher.StructOf(matcher.StructMap{"Name": "Alice"}, structs.WithContains(true)), nullUser{Name: alice}, false},
+		{"int", matcher.StructOf(matcher.StructMap{"Age": 30}, structs.WithContains(true), structs.WithUnwrapValuers()), nullUser{Age: sql.NullInt64{Int64: 30, Valid: true}}, true},
+		{"other int", matcher.StructOf(matcher.StructMap{"Age": 31}, structs.WithContains(true), structs.WithUnwrapValuers()), nullUser{Age: sql.NullInt64{Int64: 30, Valid: true}}, false},
+		{"int without option", matcher.StructOf(matcher.StructMap{"Age": 30}, structs.WithContains(true)), nullUser{Age: sql.NullInt64{Int64: 30, Valid: true}}, false},
+		{"all fields", matcher.StructOf(matcher.StructMap{
+			"ID":        id,
+			"Name":      "Alice",
+			"Age":       int64(20),
+			"DeletedAt": nil,
+			"Role":      roleMember,
+		}, structs.WithUnwrapValuers()), nullUser{
+			ID:   id,
+			Name: alice,
+			Age:  sql.NullInt64{Int64: 20, Valid: true},
+			Role: sql.Null[namedRole]{V: roleMember, Valid: true},
+		}, true},
+		{"slice of", matcher.SliceOf([]any{"Alice", nil}, slices.WithUnwrapValuers()), []sql.NullString{alice, {}}, true},
+		{"slice of with other string", matcher.SliceOf([]any{"Bob", nil}, slices.WithUnwrapValuers()), []sql.NullString{alice, {}}, false},
+		{"nil pointer", matcher.SliceOf([]any{nil}, slices.WithUnwrapValuers()), []*sql.NullString{nil}, true},
+		{"slice of ints", matcher.SliceOf([]any{1, 2}, slices.WithUnwrapValuers()), []sql.NullInt64{{Int64: 1, Valid: true}, {Int64: 2, Valid: true}}, true},
+		{"equal does not unwrap", "Alice", alice, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestEqualWithUnwrapValuers(t *testing.T) {
+	alice := sql.NullString{String: "Alice", Valid: true}
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"string", "Alice", alice, true},
+		{"other string", "Bob", alice, false},
+		{"int", 30, sql.NullInt64{Int64: 30, Valid: true}, true},
+		{"other int", 31, sql.NullInt64{Int64: 30, Valid: true}, false},
+		{"nil", nil, sql.NullString{}, true},
+		{"nil with valid", nil, alice, false},
+		{"nil pointer", nil, (*sql.NullString)(nil), true},
+		{"same null type", alice, alice, true},
+		{"matcher gets target as is", matcher.BeString(), alice, false},
+		{"not a valuer", "Alice", "Alice", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target, matcher.WithUnwrapValuers()) != tt.ans {
+				t.Errorf("Equal(%v, %v, WithUnwrapValuers()) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestNullRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect matcher.Matcher
+		target any
+		key    string
+	}{
+		{"null string", matcher.NullOf("Alice"), sql.NullString{String: "Bob", Valid: true}, "String"},
+		{"null int64", matcher.NullOf(int64(3)), sql.NullInt64{Int64: 4, Valid: true}, "Int64"},
+		{"generic null", matcher.NullOf(roleAdmin), sql.Null[namedRole]{V: roleMember, Valid: true}, "V"},
+		{"valuer", matcher.NullOf("Alice"), uuid.New(), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) {
+				t.Fatalf("Equal(%v, %v) should return false", tt.expect, tt.target)
+			}
+
+			records := Explain(tt.expect, tt.target)
+			if len(records) != 1 {
+				t.Fatalf("Length should be 1, got %d", len(records))
+			}
+
+			if records[0].Code != matcher.RecordCodeNotEqual {
+				t.Errorf("Code should be %s, got %s", matcher.RecordCodeNotEqual, records[0].Code)
+			}
+
+			if records[0].Key != tt.key {
+				t.Errorf("Key should be %q, got %q", tt.key, records[0].Key)
+			}
+		})
+	}
+}
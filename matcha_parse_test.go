@@ -0,0 +1,276 @@
+package matcha
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/version-1/go-matcha/internal/pointer"
+	"github.com/version-1/go-matcha/matcher"
+	"github.com/version-1/go-matcha/matcher/jwts"
+	"github.com/version-1/go-matcha/matcher/urls"
+)
+
+func TestParseEqual(t *testing.T) {
+	tests := []struct {
+		expr   string
+		target any
+		ans    bool
+	}{
+		{"any", 1, true},
+		{"any", 0, false},
+		{"any!zero", 0, true},
+		{"int", 1, true},
+		{"int", "1", false},
+		{"int!zero", 0, true},
+		{"string", "a", true},
+		{"string", "", false},
+		{"string!zero", "", true},
+		{"string", namedString("a"), false},
+		{"string!named", namedString("a"), true},
+		{"bool", false, true},
+		{"uuid", uuid.New(), true},
+		{"uuid", uuid.Nil, false},
+		{"uuidstring", uuid.NewString(), true},
+		{"time", time.Now(), true},
+		{"duration", time.Second, true},
+		{"duration!zero", "0s", true},
+		{"struct", dummy{1}, true},
+		{"zero", 0, true},
+		{"nil", nil, true},
+		{"nil", (*int)(nil), true},
+		{"email", "hoge@example.com", true},
+		{"*int", pointer.Ref(1), true},
+		{"*int", 1, false},
+		{"ptr(int)", pointer.Ref(1), true},
+		{"not(email)", "hoge", true},
+		{"not(email)", "hoge@example.com", false},
+		{"regexp(^a.*)", "abc", true},
+		{"regexp(^a.*)", "bc", false},
+		{"regexp(^(a|b)+$)", "abab", true},
+		{`regexp("^\\d+$")`, "123", true},
+		{"int|nil", nil, true},
+		{"int|nil", 1, true},
+		{"int|nil", "1", false},
+		{"*int|nil", (*int)(nil), true},
+		{"slice", []int{1}, true},
+		{"slice[2]", []int{1, 2}, true},
+		{"slice[2]", []int{1}, false},
+		{"slice<uuid>", []uuid.UUID{uuid.New(), uuid.New()}, true},
+		{"slice<uuid>[3]", []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}, true},
+		{"slice<uuid>[3]", []uuid.UUID{uuid.New(), uuid.New()}, false},
+		{"slice<uuid>[2]", []uuid.UUID{uuid.New(), uuid.Nil}, false},
+		{"slice< int | nil >", []any{1, nil}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s with %v", tt.expr, tt.target), func(t *testing.T) {
+			m, err := matcher.Parse(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if Equal(m, tt.target) != tt.ans {
+				t.Errorf("Equal(%s, %v) should return %v", tt.expr, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestParseString(t *testing.T) {
+	tests := []struct {
+		expr string
+		ans  string
+	}{
+		{"any", "any"},
+		{"int!zero", "int!zero"},
+		{"string !zero", "string!zero"},
+		{"string!zero!named", "string!zero!named"},
+		{"ptr(int)", "*int"},
+		{"*uuid", "*uuid"},
+		{"ptr(int|nil)", "ptr(int|nil)"},
+		{"not(email)", "not(email)"},
+		{"regexp(^a.*)", "regexp(^a.*)"},
+		{`regexp("^a\\)")`, `regexp(^a\))`},
+		{`regexp("[)]")`, `regexp("[)]")`},
+		{"slice[3]", "slice[3]"},
+		{"slice<uuid>[3]", "slice<uuid>[3]"},
+		{"slice<*time!zero>", "slice<*time!zero>"},
+		{"int | string | nil", "int|string|nil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			m := matcher.MustParse(tt.expr)
+
+			s := fmt.Sprint(m)
+			if s != tt.ans {
+				t.Errorf("String() should be %s, got %s", tt.ans, s)
+			}
+
+			if _, err := matcher.Parse(s); err != nil {
+				t.Errorf("String() should round-trip, got %s", err)
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		expr   string
+		column int
+	}{
+		{"", 1},
+		{"foo", 1},
+		{"int|foo", 5},
+		{"not(int", 8},
+		{"int!nonzero", 5},
+		{"bool!zero", 6},
+		{"uuid!named", 6},
+		{"slice[a]", 7},
+		{"slice<int", 10},
+		{"regexp(()", 10},
+		{"regexp([)", 8},
+		{"int int", 5},
+		{"uuid(version x)", 14},
+		{"uuid(foo 1)", 6},
+		{"snowflake", 10},
+		{"time(around 1s)", 6},
+		{"duration(1s)", 12},
+		{"duration(between 1s or 2s)", 21},
+		{"kind(foo)", 6},
+		{`null("a)`, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := matcher.Parse(tt.expr)
+
+			var perr matcher.ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("err should be ParseError, got %v", err)
+			}
+
+			if perr.Column != tt.column {
+				t.Errorf("Column should be %d, got %d (%s)", tt.column, perr.Column, perr)
+			}
+		})
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// Matchers configured with Go values, such as StructOf, Enum or
+	// BeOfType, have no textual form and are checked by
+	// TestParseRejectsString.
+	tests := []matcher.Matcher{
+		matcher.BeAny(),
+		matcher.BeAny().AllowZero(),
+		matcher.BeInt(),
+		matcher.BeInt().AllowZero(),
+		matcher.BeInt().AllowNamed(),
+		matcher.BeString(),
+		matcher.BeBool(),
+		matcher.BeStruct(),
+		matcher.BeSlice(),
+		matcher.SliceLen(3),
+		matcher.Each(matcher.BeUUID()),
+		matcher.BeZero(),
+		matcher.BeNil(),
+		matcher.Email(),
+		matcher.RegExp("^a.*"),
+		matcher.Not(matcher.BeInt()),
+		matcher.BeInt().Pointer(),
+		matcher.AnyOf(matcher.BeInt(), matcher.BeNil()).Pointer(),
+		matcher.BeUUID(),
+		matcher.BeUUID().Version(4, 7),
+		matcher.BeUUID().Variant(uuid.RFC4122, uuid.Microsoft),
+		matcher.BeUUID().Version(7).CreatedWithin(time.Minute).AllowZero(),
+		matcher.BeUUIDString(),
+		matcher.BeUUIDString().Version(4),
+		matcher.BeULID(),
+		matcher.BeULID().Timestamp(matcher.BeTimeWithin(time.Minute)),
+		matcher.BeKSUID().AllowZero(),
+		matcher.BeSnowflake(matcher.TwitterSnowflakeEpoch),
+		matcher.BeSnowflake(matcher.DiscordSnowflakeEpoch).Timestamp(matcher.BeTimeAfter(at)),
+		matcher.BeTime(),
+		matcher.BeTimeWithin(time.Minute),
+		matcher.BeTimeAfter(at),
+		matcher.BeTimeBefore(at.In(time.FixedZone("JST", 9*60*60))),
+		matcher.BeTimeString(matcher.LayoutRFC1123, nil),
+		matcher.BeTimeString(matcher.LayoutUnix, matcher.BeTimeWithin(time.Hour)),
+		matcher.BeDuration(),
+		matcher.BeDuration().AllowZero(),
+		matcher.BeDurationBetween(time.Second, time.Minute),
+		matcher.BeDurationApprox(time.Second, 100*time.Millisecond),
+		matcher.BeURL(),
+		matcher.BeURL(urls.WithSchemes("https", "http"), urls.WithHosts("*.example.com")),
+		matcher.BeIP(),
+		matcher.BeIPv4(),
+		matcher.BeIPv6(),
+		matcher.BeCIDR(),
+		matcher.BeHostname(),
+		matcher.BeSemver(),
+		matcher.BeBase64(),
+		matcher.BeHex(),
+		matcher.BeHex().AllowZero(),
+		matcher.BeISO8601(),
+		matcher.BeMACAddress(),
+		matcher.JWT(nil),
+		matcher.BeNull(),
+		matcher.NullOf("Alice"),
+		matcher.NullOf(42),
+		matcher.NullOf(true),
+		matcher.NullOf(1.5),
+		matcher.NullOf(2.0),
+		matcher.NullOf(matcher.BeInt()),
+		matcher.BeKind(reflect.Map),
+	}
+
+	for _, m := range tests {
+		s := fmt.Sprint(m)
+		t.Run(s, func(t *testing.T) {
+			parsed, err := matcher.Parse(s)
+			if err != nil {
+				t.Fatalf("Parse(%s) should succeed, got %s", s, err)
+			}
+
+			if got := fmt.Sprint(parsed); got != s {
+				t.Errorf("String() should round-trip as %s, got %s", s, got)
+			}
+		})
+	}
+}
+
+func TestParseRejectsString(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []matcher.Matcher{
+		matcher.BeOfType[int](),
+		matcher.Implement[fmt.Stringer](),
+		matcher.BeAssignableTo[error](),
+		matcher.Enum("a", "b"),
+		matcher.JWT(map[string]any{"sub": "1"}),
+		matcher.JWT(matcher.BeAny()),
+		matcher.JWT(nil, jwts.WithKey([]byte("secret"))),
+		matcher.JWT(nil, jwts.WithHeader(map[string]any{"alg": "HS256"})),
+		matcher.NullOf(matcher.StructOf(matcher.StructMap{"Name": "Alice"})),
+		matcher.NullOf(int64(42)),
+		matcher.NullOf(at),
+		matcher.NullOf(nil),
+		matcher.BeULID().Timestamp(at),
+	}
+
+	for _, m := range tests {
+		s := fmt.Sprint(m)
+		t.Run(s, func(t *testing.T) {
+			if _, err := matcher.Parse(s); err == nil {
+				t.Errorf("Parse(%s) should fail", s)
+			}
+		})
+	}
+}
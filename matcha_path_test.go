@@ -0,0 +1,136 @@
+package matcha
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/version-1/go-matcha/assert"
+	"github.com/version-1/go-matcha/matcher"
+	"github.com/version-1/go-matcha/matcher/structs"
+)
+
+func pathTestRecord(t *testing.T) matcher.Record {
+	expect := matcher.StructOf(matcher.StructMap{
+		"Posts": matcher.SliceOf([]any{
+			matcher.BeStruct(),
+			matcher.StructOf(matcher.StructMap{
+				"Title": "first",
+			}, structs.WithContains(true)),
+		}),
+	}, structs.WithContains(true))
+
+	target := jsonUser{Posts: []jsonPost{{Title: "zero"}, {Title: "second"}}}
+	if Equal(expect, target) {
+		t.Fatal("Equal should return false")
+	}
+
+	records := Records(expect)
+	if len(records) != 1 || len(records[0].Children) != 1 || len(records[0].Children[0].Children) != 1 {
+		t.Fatalf("records should be nested three levels, got %v", records)
+	}
+
+	return records[0].Children[0].Children[0]
+}
+
+func TestRecordPathFormatter(t *testing.T) {
+	r := pathTestRecord(t)
+
+	tests := []struct {
+		name      string
+		formatter matcher.PathFormatter
+		ans       string
+	}{
+		{"default", matcher.DefaultPath, "Posts > 1 > Title"},
+		{"go expression", matcher.GoPath, ".Posts[1].Title"},
+		{"json path", matcher.JSONPath, "$.posts[1].title"},
+		{"json pointer", matcher.JSONPointer, "/posts/1/title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if p := r.PathWith(tt.formatter); p != tt.ans {
+				t.Errorf("PathWith should return %s, got %s", tt.ans, p)
+			}
+		})
+	}
+}
+
+func TestRecordPathSegments(t *testing.T) {
+	tests := []struct {
+		name      string
+		segments  []matcher.PathSegment
+		formatter matcher.PathFormatter
+		ans       string
+	}{
+		{
+			"map key in go expression",
+			[]matcher.PathSegment{matcher.MapKeySegment("a b"), matcher.IndexSegment(0)},
+			matcher.GoPath,
+			`["a b"][0]`,
+		},
+		{
+			"map key in json path",
+			[]matcher.PathSegment{matcher.MapKeySegment("a b"), matcher.FieldSegment("ID", "id")},
+			matcher.JSONPath,
+			"$['a b'].id",
+		},
+		{
+			"escaped json pointer",
+			[]matcher.PathSegment{matcher.MapKeySegment("a/b~c"), matcher.PointerSegment("x~1y")},
+			matcher.JSONPointer,
+			"/a~1b~0c/x~1y",
+		},
+		{
+			"method result",
+			[]matcher.PathSegment{matcher.FieldSegment("User", ""), matcher.MethodSegment("Name")},
+			matcher.GoPath,
+			".User.Name()",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if p := tt.formatter(tt.segments); p != tt.ans {
+				t.Errorf("formatter should return %s, got %s", tt.ans, p)
+			}
+		})
+	}
+}
+
+func TestSetPathFormatter(t *testing.T) {
+	prev := matcher.SetPathFormatter(matcher.JSONPointer)
+	defer matcher.SetPathFormatter(prev)
+
+	r := pathTestRecord(t)
+	if r.Path() != "/posts/1/title" {
+		t.Errorf("Path should use the global formatter, got %s", r.Path())
+	}
+
+	if !strings.Contains(r.String(), "/posts/1/title") {
+		t.Errorf("String should use the global formatter, got %s", r.String())
+	}
+}
+
+func TestAssertWithPathFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	w := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(w)
+
+	expect := matcher.StructOf(matcher.StructMap{
+		"Posts": matcher.SliceOf([]any{
+			matcher.StructOf(matcher.StructMap{
+				"Title": "first",
+			}, structs.WithContains(true)),
+		}),
+	}, structs.WithContains(true))
+
+	mt := mytest{failNow: func() {}}
+	Test(mt, expect, jsonUser{Posts: []jsonPost{{Title: "second"}}}, assert.WithPathFormatter(matcher.GoPath))
+
+	if !strings.Contains(buf.String(), ".Posts[0].Title") {
+		t.Errorf("output should contain the go expression path, got %s", buf.String())
+	}
+}
@@ -0,0 +1,352 @@
+package matcha
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/version-1/go-matcha/internal/pointer"
+	"github.com/version-1/go-matcha/matcher"
+)
+
+func TestMatcherRecords(t *testing.T) {
+	v4 := uuid.New()
+
+	tests := []struct {
+		name   string
+		expect matcher.Matcher
+		target any
+		code   matcher.RecordCode
+		ans    string
+	}{
+		{"int with zero", matcher.BeInt(), 0, matcher.RecordCodeZeroValue, "Value is zero. expect a non-zero int but got 0"},
+		{"int with string", matcher.BeInt(), "1", matcher.RecordCodeUnexpectedType, "expect int but got string"},
+		{"int with nil", matcher.BeInt(), nil, matcher.RecordCodeTargetIsNil, "expect a non-zero int but got nil"},
+		{"string with zero", matcher.BeString(), "", matcher.RecordCodeZeroValue, "expect a non-zero string"},
+		{"bool with int", matcher.BeBool(), 1, matcher.RecordCodeUnexpectedType, "expect bool but got int"},
+		{"any with zero", matcher.BeAny(), 0, matcher.RecordCodeZeroValue, "expect any non-zero value"},
+		{"slice with string", matcher.BeSlice(), "a", matcher.RecordCodeUnexpectedType, "expect slice but got string"},
+		{"struct with zero", matcher.BeStruct(), dummy{}, matcher.RecordCodeZeroValue, "expect a non-zero struct"},
+		{"time with zero", matcher.BeTime(), time.Time{}, matcher.RecordCodeZeroValue, "expect a non-zero time"},
+		{"zero", matcher.BeZero(), 1, matcher.RecordCodeNotEqual, "expect: a zero value"},
+		{"nil", matcher.BeNil(), 1, matcher.RecordCodeNotEqual, "expect: nil"},
+		{"any of", matcher.AnyOf(matcher.BeNil(), matcher.BeString()), 1, matcher.RecordCodeNotEqual, "expect: nil or a non-zero string"},
+		{"regexp", matcher.RegExp("^a"), "b", matcher.RecordCodePatternMismatch, "Value does not match the pattern ^a."},
+		{"regexp with int", matcher.RegExp("^a"), 1, matcher.RecordCodeUnexpectedType, "expect string but got int"},
+		{"regexp with nil string pointer", matcher.RegExp("^a"), (*string)(nil), matcher.RecordCodeTargetIsNil, "expect a string matching ^a but got nil"},
+		{"email with nil string pointer", matcher.Email(), (*string)(nil), matcher.RecordCodeTargetIsNil, "expect an email but got nil"},
+		{"email", matcher.Email(), "alice", matcher.RecordCodeParseError, "Value is not a valid email. mail: missing '@' or angle-addr"},
+		{"slice len", matcher.SliceLen(2), []int{1}, matcher.RecordCodeUnmatchLength, "Slice length is unmatched. expect 2 but got 1"},
+		{"time within", matcher.BeTimeWithin(time.Minute), time.Now().Add(-time.Hour), matcher.RecordCodeNotEqual, "expect: a time within 1m0s of now"},
+		{"uuid with zero", matcher.BeUUID(), uuid.Nil, matcher.RecordCodeZeroValue, "expect a non-zero UUID"},
+		{"uuid string", matcher.BeUUIDString(), "abc", matcher.RecordCodeParseError, "Value is not a valid uuid. invalid UUID length: 3"},
+		{"uuid version", matcher.BeUUID().Version(7), v4, matcher.RecordCodeNotAllowed, "expect one of [VERSION_7] but got VERSION_4"},
+		{"ulid", matcher.BeULID(), "01ARZ3NDEKTSV4RRFFQ69G5FA", matcher.RecordCodeParseError, "Value is not a valid ulid. length is 25, expect 26"},
+		{"ulid with int", matcher.BeULID(), 1, matcher.RecordCodeUnexpectedType, "expect string, []byte or encoding.TextMarshaler but got int"},
+		{"ulid with byte array", matcher.BeULID(), FooULID{}, matcher.RecordCodeUnexpectedType, "expect string, []byte or encoding.TextMarshaler but got github.com/version-1/go-matcha.FooULID"},
+		{"ulid with uuid", matcher.BeULID(), v4, matcher.RecordCodeParseError, "length is 36, expect 26"},
+		{"snowflake with float", matcher.BeSnowflake(matcher.TwitterSnowflakeEpoch), 1.5, matcher.RecordCodeUnexpectedType, "expect integer or string but got float64"},
+		{"snowflake", matcher.BeSnowflake(matcher.TwitterSnowflakeEpoch), -1, matcher.RecordCodeParseError, "-1 is not positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) {
+				t.Fatalf("Equal(%v, %v) should return false", tt.expect, tt.target)
+			}
+
+			records := Explain(tt.expect, tt.target)
+			if len(records) != 1 {
+				t.Fatalf("Length should be 1, got %d", len(records))
+			}
+
+			if records[0].Code != tt.code {
+				t.Errorf("Code should be %s, got %s", tt.code, records[0].Code)
+			}
+
+			if !strings.Contains(records[0].String(), tt.ans) {
+				t.Errorf("String should contain %q, got %q", tt.ans, records[0].String())
+			}
+		})
+	}
+}
+
+func TestMatcherRecordsInStructOf(t *testing.T) {
+	m := matcher.StructOf(matcher.StructMap{
+		"Timeout": matcher.BeDuration(),
+		"Backoff": matcher.RegExp("^[0-9]+s$"),
+	})
+	if Equal(m, retryConfig{Timeout: time.Second, Backoff: "1m"}) {
+		t.Fatal("Equal should return false")
+	}
+
+	records := Records(m)
+	if len(records) != 1 || len(records[0].Children) != 1 {
+		t.Fatalf("records should have one field with one reason, got %v", records)
+	}
+
+	r := records[0].Children[0]
+	if r.Code != matcher.RecordCodePatternMismatch {
+		t.Errorf("Code should be %s, got %s", matcher.RecordCodePatternMismatch, r.Code)
+	}
+
+	if p := r.Path(); p != "Backoff" {
+		t.Errorf("Path should be Backoff, got %s", p)
+	}
+}
+
+// evenMatcher is a third-party matcher with its own record code.
+type evenMatcher struct {
+	records []matcher.Record
+}
+
+const recordCodeOdd matcher.RecordCode = "odd"
+
+func (m *evenMatcher) Match(v any) bool {
+	m.records = nil
+
+	n, ok := v.(int)
+	if !ok || n%2 != 0 {
+		m.records = append(m.records, matcher.Record{Matcher: m, Code: recordCodeOdd, Actual: v})
+		return false
+	}
+
+	return true
+}
+
+func (m evenMatcher) Not() matcher.Matcher {
+	return matcher.Not(&m)
+}
+
+func (m evenMatcher) Pointer() matcher.Matcher {
+	return matcher.Ref(&m)
+}
+
+func (m evenMatcher) Title() string {
+	return "EvenMatcher got errors."
+}
+
+func (m evenMatcher) Records() []matcher.Record {
+	return m.records
+}
+
+func (m evenMatcher) Describe() string {
+	return "an even int"
+}
+
+func TestRegisterRecordCode(t *testing.T) {
+	m := &evenMatcher{}
+	if Equal(m, 3) {
+		t.Fatal("Equal should return false")
+	}
+
+	records := Records(m)
+	if s := records[0].String(); !strings.Contains(s, "got odd error") {
+		t.Errorf("String of an unregistered code should fall back, got %q", s)
+	}
+
+	prev := matcher.RegisterRecordCode(recordCodeOdd, func(r matcher.Record, f matcher.PathFormatter) string {
+		return fmt.Sprintf("%s%v is odd. expect %s", r.Indent(), r.Actual, matcher.Describe(r.Matcher))
+	})
+	t.Cleanup(func() {
+		matcher.RegisterRecordCode(recordCodeOdd, prev)
+	})
+
+	if prev != nil {
+		t.Fatalf("RegisterRecordCode should return nil for a new code")
+	}
+
+	if s, ans := records[0].String(), "    3 is odd. expect an even int"; s != ans {
+		t.Errorf("String should be %q, got %q", ans, s)
+	}
+
+	parent := matcher.Record{Code: matcher.RecordCodeUnmatchLength, Expect: 2, Actual: 1}
+	parent.SetChildren(records)
+	if s := parent.String(); !strings.Contains(s, "\n        3 is odd. expect an even int") {
+		t.Errorf("String should render the nested record with its formatter, got %q", s)
+	}
+
+	if !slices.Contains(matcher.RecordCodes(), recordCodeOdd) {
+		t.Errorf("RecordCodes should contain %s", recordCodeOdd)
+	}
+}
+
+func TestRegisterRecordCodeNil(t *testing.T) {
+	m := &evenMatcher{}
+	if Equal(m, 3) {
+		t.Fatal("Equal should return false")
+	}
+
+	matcher.RegisterRecordCode(recordCodeOdd, func(r matcher.Record, f matcher.PathFormatter) string {
+		return "odd!"
+	})
+	if prev := matcher.RegisterRecordCode(recordCodeOdd, nil); prev == nil {
+		t.Error("RegisterRecordCode should return the replaced formatter")
+	}
+
+	if slices.Contains(matcher.RecordCodes(), recordCodeOdd) {
+		t.Errorf("RecordCodes should not contain %s", recordCodeOdd)
+	}
+
+	if s := Records(m)[0].String(); !strings.Contains(s, "got odd error") {
+		t.Errorf("String of an unregistered code should fall back, got %q", s)
+	}
+}
+
+func TestRegisterRecordCodeOverride(t *testing.T) {
+	m := matcher.BeInt()
+	if Equal(m, 0) {
+		t.Fatal("Equal should return false")
+	}
+
+	prev := matcher.RegisterRecordCode(matcher.RecordCodeZeroValue, func(r matcher.Record, f matcher.PathFormatter) string {
+		return "zero!"
+	})
+	defer matcher.RegisterRecordCode(matcher.RecordCodeZeroValue, prev)
+
+	if prev == nil {
+		t.Fatal("RegisterRecordCode should return the built-in formatter")
+	}
+
+	if s := Explain(m, 0)[0].String(); s != "zero!" {
+		t.Errorf("String should be zero!, got %q", s)
+	}
+}
+
+// The shared matchers are shared by the parallel subtests of
+// TestSharedMatcherRecords, which `go test -race` checks for data races.
+var (
+	sharedInt        = matcher.BeInt()
+	sharedURL        = matcher.BeURL()
+	sharedDuration   = matcher.BeDuration()
+	sharedTimeString = matcher.BeTimeString(matcher.LayoutRFC3339, nil)
+	sharedNull       = matcher.BeNull()
+)
+
+func TestSharedMatcherRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect matcher.Matcher
+		match  any
+		target any
+		code   matcher.RecordCode
+	}{
+		{"zero", sharedInt, 1, 0, matcher.RecordCodeZeroValue},
+		{"string", sharedInt, 1, "1", matcher.RecordCodeUnexpectedType},
+		{"nil", sharedInt, 1, nil, matcher.RecordCodeTargetIsNil},
+		{"pointer", sharedInt.Pointer(), pointer.Ref(1), 1, matcher.RecordCodeNotPointer},
+		{"not", sharedInt.Not(), "1", 1, matcher.RecordCodeUnexpectedMatch},
+		{"url", sharedURL, "https://example.com", "example.com", matcher.RecordCodeParseError},
+		{"duration", sharedDuration, "1m", "1 minute", matcher.RecordCodeParseError},
+		{"timestring", sharedTimeString, "2024-01-02T03:04:05Z", "yesterday", matcher.RecordCodeParseError},
+		{"null", sharedNull, sql.NullString{}, sql.NullString{String: "a", Valid: true}, matcher.RecordCodeNotEqual},
+	}
+
+	for i := 0; i < 4; i++ {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				if !Equal(tt.expect, tt.match) {
+					t.Errorf("Equal(%v, %v) should return true", tt.expect, tt.match)
+				}
+
+				if Equal(tt.expect, tt.target) {
+					t.Fatalf("Equal(%v, %v) should return false", tt.expect, tt.target)
+				}
+
+				records := Explain(tt.expect, tt.target)
+				if len(records) != 1 || records[0].Code != tt.code {
+					t.Errorf("records should have one %s record, got %v", tt.code, records)
+				}
+			})
+		}
+	}
+}
+
+type recordItem struct {
+	Name string
+	Tags []string
+}
+
+type recordOrder struct {
+	ID    int
+	Item  recordItem
+	Ref   *recordItem
+	Items []recordItem
+	Codes []int
+}
+
+// TestRecordString pins the messages of not equal records, which name
+// slices and the scalars in them by index and other values by field, with
+// the records of the expectation below.
+func TestRecordString(t *testing.T) {
+	item := matcher.StructMap{"Name": "a", "Tags": []string{"x"}}
+	order := func(fields matcher.StructMap) matcher.Matcher {
+		m := matcher.StructMap{
+			"ID":    1,
+			"Item":  matcher.StructOf(item),
+			"Ref":   matcher.StructOf(item).Pointer(),
+			"Items": matcher.SliceOf([]any{matcher.StructOf(item)}),
+			"Codes": []int{1, 2},
+		}
+		for k, v := range fields {
+			m[k] = v
+		}
+		return matcher.StructOf(m)
+	}
+	valid := recordOrder{
+		ID:    1,
+		Item:  recordItem{Name: "a", Tags: []string{"x"}},
+		Ref:   &recordItem{Name: "a", Tags: []string{"x"}},
+		Items: []recordItem{{Name: "a", Tags: []string{"x"}}},
+		Codes: []int{1, 2},
+	}
+	with := func(f func(o *recordOrder)) recordOrder {
+		o := valid
+		f(&o)
+		return o
+	}
+
+	tests := []struct {
+		name   string
+		expect matcher.Matcher
+		target any
+		ans    string
+	}{
+		{"field", order(nil), with(func(o *recordOrder) { o.ID = 2 }), "    Field ( ID ) didn't match.\n\n        expect: 1\n\n        got: 2"},
+		{"field with matcher", order(matcher.StructMap{"ID": matcher.BeInt()}), with(func(o *recordOrder) { o.ID = 0 }), "    Field ( ID ) didn't match.\n\n        expect: a non-zero int\n\n        got: 0\n\n        Value is zero. expect a non-zero int but got 0"},
+		{"nested struct", order(nil), with(func(o *recordOrder) { o.Item = recordItem{Name: "b", Tags: []string{"x"}} }), "    Field ( Item ) didn't match.\n\n        expect: a struct of {Name: \"a\", Tags: [x]}\n\n        got: matcha.recordItem{Name:\"b\", Tags:[]string{\"x\"}}\n\n        Field ( Item > Name ) didn't match.\n\n                expect: a\n\n                got: b"},
+		{"nested struct pointer", order(nil), with(func(o *recordOrder) { o.Ref = &recordItem{Name: "b", Tags: []string{"x"}} }), "    Field ( Ref ) didn't match.\n\n        expect: a pointer to a struct of {Name: \"a\", Tags: [x]}\n\n        got: &matcha.recordItem{Name:\"b\", Tags:[]string{\"x\"}}\n\n        Field ( Ref > Name ) didn't match.\n\n                expect: a\n\n                got: b"},
+		{"slice literal field", order(nil), with(func(o *recordOrder) { o.Codes = []int{1, 3} }), "    Index ( Codes ) didn't match.\n\n        expect: [1 2]\n\n        got: [1 3]"},
+		{"nested slice literal", order(nil), with(func(o *recordOrder) { o.Item = recordItem{Name: "a", Tags: []string{"y"}} }), "    Field ( Item ) didn't match.\n\n        expect: a struct of {Name: \"a\", Tags: [x]}\n\n        got: matcha.recordItem{Name:\"a\", Tags:[]string{\"y\"}}\n\n        Index ( Item > Tags ) didn't match.\n\n                expect: [x]\n\n                got: [y]"},
+		{"slice of structs", order(nil), with(func(o *recordOrder) { o.Items = []recordItem{{Name: "b", Tags: []string{"x"}}} }), "    Index ( Items ) didn't match.\n\n        expect: a slice of [a struct of {Name: \"a\", Tags: [x]}]\n\n        got: [{b [x]}]\n\n        Field ( Items > 0 ) didn't match.\n\n                expect: a struct of {Name: \"a\", Tags: [x]}\n\n                got: matcha.recordItem{Name:\"b\", Tags:[]string{\"x\"}}\n\n            Field ( Items > 0 > Name ) didn't match.\n\n                        expect: a\n\n                        got: b"},
+		{"slice of structs length", order(nil), with(func(o *recordOrder) { o.Items = nil }), "    Index ( Items ) didn't match.\n\n        expect: a slice of [a struct of {Name: \"a\", Tags: [x]}]\n\n        got: []\n\n        Slice length is unmatched. expect 1 but got 0\n\n            Index: Items > 0 was removed.\n\n                        expect: a struct of {Name: \"a\", Tags: [x]}\n\n"},
+		{"slice element", matcher.SliceOf([]any{1, 2, 3}), []int{1, 5, 3}, "    Index ( 1 ) didn't match.\n\n        expect: 2\n\n        got: 5"},
+		{"slice element matcher", matcher.SliceOf([]any{1, matcher.BeString(), 3}), []any{1, 2, 3}, "    Index ( 1 ) didn't match.\n\n        expect: a non-zero string\n\n        got: 2\n\n        Target is unexpected type. expect string but got int"},
+		{"slice length", matcher.SliceOf([]any{1, 2, 3}), []int{1, 3}, "    Slice length is unmatched. expect 3 but got 2\n\n        Index: 1 was removed.\n\n                expect: 2\n\n"},
+		{"slice length insert", matcher.SliceOf([]any{1, 2}), []int{0, 1, 2}, "    Slice length is unmatched. expect 2 but got 3\n\n        Index: 0 was inserted.\n\n                got: 0\n\n"},
+		{"root slice literal", matcher.StructOf(matcher.StructMap{"Tags": []string{"x", "y"}, "Name": "a"}), recordItem{Name: "a", Tags: []string{"x"}}, "    Index ( Tags ) didn't match.\n\n        expect: [x y]\n\n        got: [x]"},
+		{"not", order(matcher.StructMap{"Item": matcher.StructOf(item).Not()}), valid, "    Field ( Item ) didn't match.\n\n        expect: not a struct of {Name: \"a\", Tags: [x]}\n\n        got: matcha.recordItem{Name:\"a\", Tags:[]string{\"x\"}}\n\n        Value matched unexpectedly. expected not to match a struct of {Name: \"a\", Tags: [x]}, but it did\n\n                got: {a [x]}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) {
+				t.Fatalf("Equal(%v, %v) should return false", tt.expect, tt.target)
+			}
+
+			list := []string{}
+			for _, r := range Explain(tt.expect, tt.target) {
+				list = append(list, r.String())
+			}
+
+			if s := strings.Join(list, "\n\n"); s != tt.ans {
+				t.Errorf("String should be %q, got %q", tt.ans, s)
+			}
+		})
+	}
+}
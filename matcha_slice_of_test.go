@@ -49,6 +49,44 @@ func TestSliceOfEqual(t *testing.T) {
 			[]int{1, 2, 4, 5},
 			false,
 		},
+		// unordered
+		{
+			"slice of matcher without order: match",
+			matcher.SliceOf(
+				[]any{3, 1, 2},
+				slices.WithPersistOrder(false),
+			),
+			[]int{1, 2, 3},
+			true,
+		},
+		{
+			"slice of matcher without order: loose matcher does not steal element",
+			matcher.SliceOf(
+				[]any{matcher.BeString(), "a"},
+				slices.WithPersistOrder(false),
+			),
+			[]string{"a", "b"},
+			true,
+		},
+		{
+			"slice of matcher without order: matchers compete for one element",
+			matcher.SliceOf(
+				[]any{"a", matcher.RegExp("^a")},
+				slices.WithPersistOrder(false),
+			),
+			[]string{"a", "b"},
+			false,
+		},
+		{
+			"slice of matcher without order and with contains: match",
+			matcher.SliceOf(
+				[]any{matcher.BeInt(), 5},
+				slices.WithPersistOrder(false),
+				slices.WithContains(true),
+			),
+			[]int{5, 1, 2},
+			true,
+		},
 		// with matcher
 		{
 			"slice of matcher with matcher: match",
@@ -310,6 +348,56 @@ func TestSliceOfNotEqual(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "unordered with no candidate, conflict and leftover",
+			expect: matcher.SliceOf(
+				[]any{"a", matcher.RegExp("^a"), "z"},
+				slices.WithPersistOrder(false),
+			),
+			target: []string{"a", "b", "c"},
+			ans: []matcher.Record{
+				{
+					Code:   matcher.RecordCodeConflict,
+					Key:    "1",
+					Actual: []int{0},
+				},
+				{
+					Code: matcher.RecordCodeNotFound,
+					Key:  "2",
+				},
+				{
+					Code:   matcher.RecordCodeUnexpectedElement,
+					Key:    "1",
+					Actual: "b",
+				},
+				{
+					Code:   matcher.RecordCodeUnexpectedElement,
+					Key:    "2",
+					Actual: "c",
+				},
+			},
+			assert: func(expect, target any, ans []matcher.Record) {
+				Equal(expect, target)
+				records := Records(expect)
+				if len(records) != len(ans) {
+					t.Fatalf("Length should be %d, got %d", len(ans), len(records))
+				}
+
+				for i, r := range records {
+					if r.Code != ans[i].Code {
+						t.Errorf("r.Code should be %s, got %s", ans[i].Code, r.Code)
+					}
+
+					if r.Key != ans[i].Key {
+						t.Errorf("r.Key should be %s, got %s", ans[i].Key, r.Key)
+					}
+
+					if ans[i].Actual != nil && !reflect.DeepEqual(r.Actual, ans[i].Actual) {
+						t.Errorf("r.Actual should be %v, got %v", ans[i].Actual, r.Actual)
+					}
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -338,3 +426,110 @@ func TestSliceLenEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestSliceOfDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    []matcher.Record
+	}{
+		{
+			name:   "inserted element",
+			expect: matcher.SliceOf([]any{"a", "b", "c"}),
+			target: []string{"a", "x", "b", "c"},
+			ans: []matcher.Record{
+				{Code: matcher.RecordCodeInserted, Key: "1", Actual: "x"},
+			},
+		},
+		{
+			name:   "removed element",
+			expect: matcher.SliceOf([]any{"a", "b", "c"}),
+			target: []string{"a", "c"},
+			ans: []matcher.Record{
+				{Code: matcher.RecordCodeRemoved, Key: "1", Expect: "b"},
+			},
+		},
+		{
+			name:   "removed elements are indexed by the target",
+			expect: matcher.SliceOf([]any{"a", "b", "c", "d"}),
+			target: []string{"a", "d"},
+			ans: []matcher.Record{
+				{Code: matcher.RecordCodeRemoved, Key: "1", Expect: "b"},
+				{Code: matcher.RecordCodeRemoved, Key: "1", Expect: "c"},
+			},
+		},
+		{
+			name:   "removed and inserted elements",
+			expect: matcher.SliceOf([]any{"a", "b", "c"}),
+			target: []string{"x", "y", "z", "a", "c"},
+			ans: []matcher.Record{
+				{Code: matcher.RecordCodeInserted, Key: "0", Actual: "x"},
+				{Code: matcher.RecordCodeInserted, Key: "1", Actual: "y"},
+				{Code: matcher.RecordCodeInserted, Key: "2", Actual: "z"},
+				{Code: matcher.RecordCodeRemoved, Key: "4", Expect: "b"},
+			},
+		},
+		{
+			name:   "changed and inserted elements",
+			expect: matcher.SliceOf([]any{"a", "b", "c"}),
+			target: []string{"a", "B", "c", "d"},
+			ans: []matcher.Record{
+				{Code: matcher.RecordCodeNotEqual, Key: "1", Expect: "b", Actual: "B"},
+				{Code: matcher.RecordCodeInserted, Key: "3", Actual: "d"},
+			},
+		},
+		{
+			name:   "matcher aware",
+			expect: matcher.SliceOf([]any{matcher.BeInt(), "a"}),
+			target: []any{1, 2, "a"},
+			ans: []matcher.Record{
+				{Code: matcher.RecordCodeInserted, Key: "1", Actual: 2},
+			},
+		},
+		{
+			name:   "unordered",
+			expect: matcher.SliceOf([]any{"c", "a"}, slices.WithPersistOrder(false)),
+			target: []string{"a", "b", "c"},
+			ans: []matcher.Record{
+				{Code: matcher.RecordCodeUnexpectedElement, Key: "1", Actual: "b"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) {
+				t.Fatal("Equal should return false")
+			}
+
+			records := Records(tt.expect)
+			if len(records) != 1 || records[0].Code != matcher.RecordCodeUnmatchLength {
+				t.Fatalf("records should have one unmatch length record, got %v", records)
+			}
+
+			children := records[0].Children
+			if len(children) != len(tt.ans) {
+				t.Fatalf("Length should be %d, got %d", len(tt.ans), len(children))
+			}
+
+			for i, r := range children {
+				if r.Code != tt.ans[i].Code {
+					t.Errorf("r.Code should be %s, got %s", tt.ans[i].Code, r.Code)
+				}
+
+				if r.Key != tt.ans[i].Key {
+					t.Errorf("r.Key should be %s, got %s", tt.ans[i].Key, r.Key)
+				}
+
+				if r.Expect != tt.ans[i].Expect {
+					t.Errorf("r.Expect should be %v, got %v", tt.ans[i].Expect, r.Expect)
+				}
+
+				if r.Actual != tt.ans[i].Actual {
+					t.Errorf("r.Actual should be %v, got %v", tt.ans[i].Actual, r.Actual)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package matcha
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/version-1/go-matcha/matcher"
+)
+
+type snapshotTest struct {
+	mytest
+	name string
+}
+
+func (s snapshotTest) Name() string {
+	return s.name
+}
+
+func TestSnapshot(t *testing.T) {
+	newUser := func() user {
+		return user{
+			ID:        uuid.New(),
+			Name:      "John Doe",
+			Age:       25,
+			CreatedAt: time.Now(),
+			Posts: []post{
+				{ID: uuid.New(), Title: "first"},
+				{ID: uuid.New(), Title: "second"},
+			},
+		}
+	}
+
+	masks := []func(*SnapshotOptions){
+		Mask("ID", matcher.BeUUID()),
+		Mask("CreatedAt", matcher.BeTime()),
+		Mask("Posts.*.ID", matcher.BeUUID()),
+	}
+
+	tests := []struct {
+		name    string
+		subject func(dir string)
+	}{
+		{
+			name: "writes the snapshot on the first run and matches on the next one",
+			subject: func(dir string) {
+				failNowCalled := false
+				mt := snapshotTest{mytest{failNow: func() { failNowCalled = true }}, "TestUser/first"}
+
+				Snapshot(mt, newUser(), append(masks, WithSnapshotDir(dir))...)
+				Snapshot(mt, newUser(), append(masks, WithSnapshotDir(dir))...)
+
+				if failNowCalled {
+					t.Errorf("failNow should not be called")
+				}
+
+				b, err := os.ReadFile(filepath.Join(dir, "TestUser_first.snap"))
+				if err != nil {
+					t.Fatalf("snapshot should be written: %s", err)
+				}
+
+				if !strings.Contains(string(b), "ID: <<masked>>") {
+					t.Errorf("masked field should be replaced with placeholder, got %s", b)
+				}
+			},
+		},
+		{
+			name: "fails when the value changes",
+			subject: func(dir string) {
+				failNowCalled := false
+				mt := snapshotTest{mytest{failNow: func() { failNowCalled = true }}, "TestUser"}
+
+				u := newUser()
+				Snapshot(mt, u, append(masks, WithSnapshotDir(dir))...)
+
+				u.Name = "Jane Doe"
+				Snapshot(mt, u, append(masks, WithSnapshotDir(dir))...)
+
+				if !failNowCalled {
+					t.Errorf("failNow should be called")
+				}
+			},
+		},
+		{
+			name: "fails when a masked field does not match its matcher",
+			subject: func(dir string) {
+				failNowCalled := false
+				mt := snapshotTest{mytest{failNow: func() { failNowCalled = true }}, "TestUser"}
+
+				u := newUser()
+				u.ID = uuid.Nil
+				Snapshot(mt, u, append(masks, WithSnapshotDir(dir))...)
+
+				if !failNowCalled {
+					t.Errorf("failNow should be called")
+				}
+			},
+		},
+		{
+			name: "fails when a mask path does not exist",
+			subject: func(dir string) {
+				failNowCalled := false
+				mt := snapshotTest{mytest{failNow: func() { failNowCalled = true }}, "TestUser"}
+
+				Snapshot(mt, newUser(), Mask("Unknown", matcher.BeAny()), WithSnapshotDir(dir))
+
+				if !failNowCalled {
+					t.Errorf("failNow should be called")
+				}
+			},
+		},
+		{
+			name: "rewrites the snapshot with update",
+			subject: func(dir string) {
+				failNowCalled := false
+				mt := snapshotTest{mytest{failNow: func() { failNowCalled = true }}, "TestUser"}
+
+				u := newUser()
+				Snapshot(mt, u, append(masks, WithSnapshotDir(dir))...)
+
+				u.Name = "Jane Doe"
+				Snapshot(mt, u, append(masks, WithSnapshotDir(dir), WithUpdate(true))...)
+				Snapshot(mt, u, append(masks, WithSnapshotDir(dir))...)
+
+				if failNowCalled {
+					t.Errorf("failNow should not be called")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.subject(t.TempDir())
+		})
+	}
+}
+
+func TestSnapshotUpdateEnv(t *testing.T) {
+	t.Setenv(snapshotEnvKey, "1")
+
+	dir := t.TempDir()
+	failNowCalled := false
+	mt := snapshotTest{mytest{failNow: func() { failNowCalled = true }}, "TestUser"}
+
+	Snapshot(mt, map[string]string{"name": "John Doe"}, WithSnapshotDir(dir))
+	Snapshot(mt, map[string]string{"name": "Jane Doe"}, WithSnapshotDir(dir))
+
+	if failNowCalled {
+		t.Errorf("failNow should not be called with %s=1", snapshotEnvKey)
+	}
+
+	if flag.Lookup("update") != nil {
+		t.Errorf("matcha should not register the update flag")
+	}
+}
+
+func TestSnapshotFileName(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect string
+	}{
+		{name: "TestUser", expect: "TestUser.snap"},
+		{name: "TestUser/first", expect: "TestUser_first.snap"},
+		{name: "TestUser_first", expect: "TestUser%5Ffirst.snap"},
+		{name: "TestUser/a_/b", expect: "TestUser_a%5F_b.snap"},
+		{name: "TestUser/a/_b", expect: "TestUser_a_%5Fb.snap"},
+		{name: "TestUser/50%", expect: "TestUser_50%25.snap"},
+		{name: `TestUser/C:\tmp`, expect: "TestUser_C%3A%5Ctmp.snap"},
+	}
+
+	seen := map[string]string{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := snapshotFileName(tt.name)
+			if got != tt.expect {
+				t.Errorf("expect %s but got %s", tt.expect, got)
+			}
+
+			if other, ok := seen[got]; ok {
+				t.Errorf("%s and %s share the file %s", other, tt.name, got)
+			}
+			seen[got] = tt.name
+		})
+	}
+}
+
+func TestRegisterUpdateFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	if !registerUpdateFlag(fs) {
+		t.Errorf("the update flag should be registered")
+	}
+
+	if registerUpdateFlag(fs) {
+		t.Errorf("an existing update flag should be left alone")
+	}
+
+	if err := fs.Parse([]string{"-update"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := fs.Lookup("update").Value.String(); got != "true" {
+		t.Errorf("expect the update flag to be true but got %s", got)
+	}
+}
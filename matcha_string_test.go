@@ -50,6 +50,8 @@ func TestRegexpEqual(t *testing.T) {
 		{"not matcher with string", matcher.RegExp("1234"), "123", false},
 		{"regexp matcher with nil", matcher.RegExp("^[0-9]+$"), nil, false},
 		{"regexp matcher with not string", matcher.RegExp("^[0-9]+$"), 123, false},
+		{"regexp matcher with string pointer", matcher.RegExp("^[0-9]+$"), pointer.Ref("123"), true},
+		{"regexp matcher with nil string pointer", matcher.RegExp("^[0-9]+$"), (*string)(nil), false},
 	}
 
 	for _, tt := range tests {
@@ -75,6 +77,8 @@ func TestEmailEqual(t *testing.T) {
 		{"not matcher with not-email string 2", matcher.Email(), "example.com", false},
 		{"not matcher with not string", matcher.Email(), 123, false},
 		{"not matcher with nil", matcher.Email(), nil, false},
+		{"email matcher with email string pointer", matcher.Email(), pointer.Ref("hoge@example.com"), true},
+		{"not matcher with nil string pointer", matcher.Email(), (*string)(nil), false},
 	}
 
 	for _, tt := range tests {
@@ -148,6 +148,27 @@ func TestStructOfEqual(t *testing.T) {
 			"UpdatedAt":  time.Time{},
 			"WrongField": "",
 		}), user{}, false},
+		{"row target: match", matcher.StructOf(map[string]any{
+			"id":   1,
+			"name": matcher.BeString(),
+		}), matcher.Row{"id": 1, "name": "alice"}, true},
+		{"row target: missing key", matcher.StructOf(map[string]any{
+			"id":   1,
+			"name": matcher.BeString(),
+		}), matcher.Row{"id": 1, "nam": "alice"}, false},
+		{"row target: contains", matcher.StructOf(map[string]any{
+			"id": 1,
+		}, structs.WithContains(true)), matcher.Row{"id": 1, "name": "alice"}, true},
+		{"map target", matcher.StructOf(map[string]any{
+			"id":   1,
+			"name": "alice",
+		}), map[string]any{"id": 1, "name": "alice"}, false},
+		{"map target: contains", matcher.StructOf(map[string]any{
+			"id": 1,
+		}, structs.WithContains(true)), map[string]any{"id": 1}, false},
+		{"map target: not string key", matcher.StructOf(map[string]any{
+			"id": 1,
+		}), map[int]any{1: 1}, false},
 	}
 
 	for _, tt := range tests {
@@ -225,6 +246,34 @@ func TestStructOfNotMatch(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "target is a map",
+			expect: matcher.StructOf(matcher.StructMap{
+				"ID": uuid.Nil,
+			}),
+			target: map[string]any{"ID": uuid.Nil},
+			ans: []matcher.Record{
+				{
+					Code:   matcher.RecordCodeUnexpectedType,
+					Expect: "Struct",
+				},
+			},
+			assert: func(expect, target any, ans []matcher.Record) {
+				Equal(expect, target)
+				test := assert.New(t, expect, target)
+				records := test.Records()
+
+				if len(records) != len(ans) {
+					t.Fatalf("records should have %d records, got %v", len(ans), records)
+				}
+
+				for i, r := range records {
+					if r.Code != ans[i].Code {
+						t.Errorf("r.Code should be %s, got %s", ans[i].Code, r.Code)
+					}
+				}
+			},
+		},
 		{
 			name: "wrong field error",
 			expect: matcher.StructOf(matcher.StructMap{
@@ -371,3 +420,98 @@ func TestStructOfNotMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestStructOfFieldRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect any
+		ans    []matcher.Record
+	}{
+		{
+			name: "missing and misspelled fields",
+			expect: matcher.StructOf(matcher.StructMap{
+				"EmbededUser": EmbededUser{},
+				"ID":          uuid.Nil,
+				"GroupID":     uuid.Nil,
+				"Nmae":        "",
+				"Age":         0,
+				"Status":      "",
+				"CreatedAt":   time.Time{},
+				"UpdatedAt":   time.Time{},
+			}),
+			ans: []matcher.Record{
+				{Key: "Nmae", Code: matcher.RecordCodeNotFound, Suggestions: []string{"Name"}},
+				{Key: "Name", Code: matcher.RecordCodeUnexpectedField},
+				{Key: "Group", Code: matcher.RecordCodeUnexpectedField},
+				{Key: "Posts", Code: matcher.RecordCodeUnexpectedField},
+			},
+		},
+		{
+			name: "unknown field without suggestion",
+			expect: matcher.StructOf(matcher.StructMap{
+				"EmbededUser": EmbededUser{},
+				"ID":          uuid.Nil,
+				"GroupID":     uuid.Nil,
+				"Name":        "",
+				"Age":         0,
+				"Status":      "",
+				"CreatedAt":   time.Time{},
+				"UpdatedAt":   time.Time{},
+				"Group":       (*group)(nil),
+				"Posts":       []post(nil),
+				"Password":    "",
+			}),
+			ans: []matcher.Record{
+				{Key: "Password", Code: matcher.RecordCodeNotFound},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, user{}) {
+				t.Fatal("Equal should return false")
+			}
+
+			records := Records(tt.expect)
+			if len(records) != 1 || records[0].Code != matcher.RecordCodeUnmatchLength {
+				t.Fatalf("records should have one unmatch length record, got %v", records)
+			}
+
+			children := records[0].Children
+			if len(children) != len(tt.ans) {
+				t.Fatalf("Length should be %d, got %d", len(tt.ans), len(children))
+			}
+
+			for i, r := range children {
+				if r.Key != tt.ans[i].Key {
+					t.Errorf("r.Key should be %s, got %s", tt.ans[i].Key, r.Key)
+				}
+
+				if r.Code != tt.ans[i].Code {
+					t.Errorf("r.Code should be %s, got %s", tt.ans[i].Code, r.Code)
+				}
+
+				if fmt.Sprint(r.Suggestions) != fmt.Sprint(tt.ans[i].Suggestions) {
+					t.Errorf("r.Suggestions should be %v, got %v", tt.ans[i].Suggestions, r.Suggestions)
+				}
+			}
+		})
+	}
+}
+
+func TestStructOfSuggestion(t *testing.T) {
+	expect := matcher.StructOf(matcher.StructMap{
+		"name": "",
+	}, structs.WithContains(true))
+
+	Equal(expect, user{})
+	records := Records(expect)
+	if len(records) != 1 {
+		t.Fatalf("Length should be 1, got %d", len(records))
+	}
+
+	if fmt.Sprint(records[0].Suggestions) != "[Name]" {
+		t.Errorf("r.Suggestions should be [Name], got %v", records[0].Suggestions)
+	}
+}
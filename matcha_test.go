@@ -1,6 +1,7 @@
 package matcha
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -102,6 +103,40 @@ func TestMatchaTest(t *testing.T) {
 
 				Test(mt, matcher.BeInt(), "123")
 
+				if !failNowCalled {
+					t.Errorf("failNow should be called")
+				}
+			},
+		},
+		{
+			name: "when not matcher fails, fail now is called",
+			subject: func() {
+				failNowCalled := false
+				mt := mytest{
+					failNow: func() {
+						failNowCalled = true
+					},
+				}
+
+				Test(mt, matcher.StructOf(matcher.StructMap{"Timeout": time.Second, "Backoff": "1s"}).Not(), retryConfig{Timeout: time.Second, Backoff: "1s"})
+
+				if !failNowCalled {
+					t.Errorf("failNow should be called")
+				}
+			},
+		},
+		{
+			name: "when ref matcher fails with non pointer, fail now is called",
+			subject: func() {
+				failNowCalled := false
+				mt := mytest{
+					failNow: func() {
+						failNowCalled = true
+					},
+				}
+
+				Test(mt, matcher.BeInt().Pointer(), 1)
+
 				if !failNowCalled {
 					t.Errorf("failNow should be called")
 				}
@@ -271,6 +306,50 @@ func TestNotEqual(t *testing.T) {
 	}
 }
 
+func TestWrapperRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect matcher.Matcher
+		target any
+		code   matcher.RecordCode
+		ans    string
+	}{
+		{"not", matcher.BeInt().Not(), 1, matcher.RecordCodeUnexpectedMatch, "expected not to match a non-zero int, but it did"},
+		{"not struct of", matcher.StructOf(matcher.StructMap{
+			"Timeout": time.Second,
+			"Backoff": "1s",
+		}).Not(), retryConfig{Timeout: time.Second, Backoff: "1s"}, matcher.RecordCodeUnexpectedMatch, `expected not to match a struct of {Backoff: "1s", Timeout: 1s}, but it did`},
+		{"ref with non pointer", matcher.BeInt().Pointer(), 1, matcher.RecordCodeNotPointer, "expected a pointer but got int"},
+		{"ref with unmatched element", matcher.BeInt().Pointer(), pointer.Ref("1"), matcher.RecordCodeUnexpectedType, "expect int but got string"},
+		{"ref with recorder", matcher.StructOf(matcher.StructMap{
+			"Timeout": time.Second,
+			"Backoff": "1s",
+		}).Pointer(), &retryConfig{Timeout: time.Second, Backoff: "2s"}, matcher.RecordCodeNotEqual, "Field ( Backoff ) didn't match"},
+		{"ref with nil", matcher.BeInt().Pointer(), nil, matcher.RecordCodeTargetIsNil, "expect a non-zero int but got nil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) {
+				t.Fatalf("Equal(%v, %v) should return false", tt.expect, tt.target)
+			}
+
+			records := Explain(tt.expect, tt.target)
+			if len(records) != 1 {
+				t.Fatalf("Length should be 1, got %d", len(records))
+			}
+
+			if records[0].Code != tt.code {
+				t.Errorf("Code should be %s, got %s", tt.code, records[0].Code)
+			}
+
+			if !strings.Contains(records[0].String(), tt.ans) {
+				t.Errorf("String should contain %q, got %q", tt.ans, records[0].String())
+			}
+		})
+	}
+}
+
 func TestZeroEqual(t *testing.T) {
 	tests := []struct {
 		name   string
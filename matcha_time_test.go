@@ -0,0 +1,100 @@
+package matcha
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/version-1/go-matcha/internal/pointer"
+	"github.com/version-1/go-matcha/matcher"
+)
+
+func TestTimeStringEqual(t *testing.T) {
+	now := time.Now()
+	ref := time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"rfc3339", matcher.BeTimeString(matcher.LayoutRFC3339, nil), "2024-01-02T03:04:05Z", true},
+		{"rfc3339 with string pointer", matcher.BeTimeString(matcher.LayoutRFC3339, nil), pointer.Ref("2024-01-02T03:04:05+09:00"), true},
+		{"rfc3339 with named string", matcher.BeTimeString(matcher.LayoutRFC3339, nil), namedString("2024-01-02T03:04:05Z"), true},
+		{"rfc3339 invalid", matcher.BeTimeString(matcher.LayoutRFC3339, nil), "2024-01-02 03:04:05", false},
+		{"rfc3339 with time", matcher.BeTimeString(matcher.LayoutRFC3339, nil), ref, false},
+		{"rfc3339 nano", matcher.BeTimeString(matcher.LayoutRFC3339Nano, matcher.BeTimeAfter(ref.Add(-time.Nanosecond))), ref.Format(time.RFC3339Nano), true},
+		{"rfc1123", matcher.BeTimeString(matcher.LayoutRFC1123, nil), "Tue, 02 Jan 2024 03:04:05 UTC", true},
+		{"custom layout", matcher.BeTimeString("2006/01/02", nil), "2024/01/02", true},
+		{"inner matcher", matcher.BeTimeString(matcher.LayoutRFC3339, matcher.BeTimeWithin(time.Minute)), now.Format(time.RFC3339), true},
+		{"inner matcher not match", matcher.BeTimeString(matcher.LayoutRFC3339, matcher.BeTimeAfter(now)), "2024-01-02T03:04:05Z", false},
+		{"unix seconds", matcher.BeTimeString(matcher.LayoutUnix, matcher.BeTimeWithin(time.Minute)), now.Unix(), true},
+		{"unix seconds as float", matcher.BeTimeString(matcher.LayoutUnix, nil), float64(now.Unix()), true},
+		{"unix seconds as fractional float", matcher.BeTimeString(matcher.LayoutUnix, nil), 1.5, false},
+		{"unix seconds as json number", matcher.BeTimeString(matcher.LayoutUnix, nil), json.Number("1704164645"), true},
+		{"unix seconds as string", matcher.BeTimeString(matcher.LayoutUnix, nil), strconv.FormatInt(now.Unix(), 10), true},
+		{"unix seconds as other string", matcher.BeTimeString(matcher.LayoutUnix, nil), "now", false},
+		{"unix millis", matcher.BeTimeString(matcher.LayoutUnixMilli, matcher.BeTimeWithin(time.Minute)), now.UnixMilli(), true},
+		{"unix millis read as seconds", matcher.BeTimeString(matcher.LayoutUnix, matcher.BeTimeWithin(time.Minute)), now.UnixMilli(), false},
+		{"ref", matcher.BeTimeString(matcher.LayoutRFC3339, nil).Pointer(), pointer.Ref("2024-01-02T03:04:05Z"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestTimeStringRecords(t *testing.T) {
+	after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		expect matcher.Matcher
+		target any
+		code   matcher.RecordCode
+		ans    string
+	}{
+		{
+			"parse error",
+			matcher.BeTimeString(matcher.LayoutRFC3339, nil),
+			"2024-01-02",
+			matcher.RecordCodeParseError,
+			`Value is not a valid 2006-01-02T15:04:05Z07:00. parsing time "2024-01-02"`,
+		},
+		{
+			"inner not match",
+			matcher.BeTimeString(matcher.LayoutRFC3339, matcher.BeTimeAfter(after)),
+			"2024-01-02T03:04:05Z",
+			matcher.RecordCodeNotEqual,
+			"got: 2024-01-02 03:04:05 +0000 UTC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) {
+				t.Fatalf("Equal(%v, %v) should return false", tt.expect, tt.target)
+			}
+
+			records := Explain(tt.expect, tt.target)
+			if len(records) != 1 {
+				t.Fatalf("Length should be 1, got %d", len(records))
+			}
+
+			if records[0].Code != tt.code {
+				t.Errorf("Code should be %s, got %s", tt.code, records[0].Code)
+			}
+
+			if !strings.Contains(records[0].String(), tt.ans) {
+				t.Errorf("String should contain %q, got %q", tt.ans, records[0].String())
+			}
+		})
+	}
+}
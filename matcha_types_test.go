@@ -0,0 +1,128 @@
+package matcha
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/version-1/go-matcha/internal/pointer"
+	"github.com/version-1/go-matcha/matcher"
+)
+
+type namedString string
+
+func (s namedString) String() string {
+	return string(s)
+}
+
+func TestTypeEqual(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		// BeOfType
+		{"of type with same type", matcher.BeOfType[uuid.UUID](), uuid.New(), true},
+		{"of type with zero value", matcher.BeOfType[int](), 0, true},
+		{"of type with underlying type", matcher.BeOfType[string](), namedString("a"), false},
+		{"of type with pointer", matcher.BeOfType[*dummy](), &dummy{}, true},
+		{"of type with value of pointer type", matcher.BeOfType[dummy](), &dummy{}, false},
+		{"of type with nil", matcher.BeOfType[*dummy](), nil, false},
+		{"of type ref", matcher.BeOfType[int]().Pointer(), pointer.Ref(1), true},
+		{"not of type", matcher.BeOfType[int]().Not(), "1", true},
+		// Implement
+		{"implement with implementation", matcher.Implement[fmt.Stringer](), namedString("a"), true},
+		{"implement with pointer receiver", matcher.Implement[error](), errors.New("a"), true},
+		{"implement without implementation", matcher.Implement[fmt.Stringer](), "a", false},
+		{"implement with nil", matcher.Implement[error](), nil, false},
+		{"implement empty interface", matcher.Implement[any](), 1, true},
+		// BeKind
+		{"kind map", matcher.BeKind(reflect.Map), map[string]int{}, true},
+		{"kind map with slice", matcher.BeKind(reflect.Map), []int{}, false},
+		{"kind string with named string", matcher.BeKind(reflect.String), namedString("a"), true},
+		{"kind with nil", matcher.BeKind(reflect.Pointer), nil, false},
+		// BeAssignableTo
+		{"assignable to interface", matcher.BeAssignableTo[io.Reader](), strings.NewReader("a"), true},
+		{"assignable to same type", matcher.BeAssignableTo[int](), 1, true},
+		{"assignable to underlying type", matcher.BeAssignableTo[string](), namedString("a"), false},
+		{"assignable to unrelated interface", matcher.BeAssignableTo[io.Writer](), strings.NewReader("a"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestTypeRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect matcher.Matcher
+		target any
+		ans    string
+	}{
+		{
+			"of type",
+			matcher.BeOfType[dummy](),
+			&dummy{},
+			"expect github.com/version-1/go-matcha.dummy but got *github.com/version-1/go-matcha.dummy",
+		},
+		{
+			"implement",
+			matcher.Implement[fmt.Stringer](),
+			[]uuid.UUID{},
+			"expect implementation of fmt.Stringer but got []github.com/google/uuid.UUID",
+		},
+		{
+			"kind",
+			matcher.BeKind(reflect.Map),
+			nil,
+			"expect kind map but got nil",
+		},
+		{
+			"assignable",
+			matcher.BeAssignableTo[io.Reader](),
+			map[string]*dummy{},
+			"expect assignable to io.Reader but got map[string]*github.com/version-1/go-matcha.dummy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) {
+				t.Fatalf("Equal(%v, %v) should return false", tt.expect, tt.target)
+			}
+
+			records := Records(tt.expect)
+			if len(records) != 1 {
+				t.Fatalf("Length should be 1, got %d", len(records))
+			}
+
+			if records[0].Code != matcher.RecordCodeUnexpectedType {
+				t.Errorf("Code should be %s, got %s", matcher.RecordCodeUnexpectedType, records[0].Code)
+			}
+
+			if !strings.Contains(records[0].String(), tt.ans) {
+				t.Errorf("String should contain %q, got %q", tt.ans, records[0].String())
+			}
+		})
+	}
+}
+
+func TestImplementPanicsWithoutInterface(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Implement[int] should panic")
+		}
+	}()
+
+	matcher.Implement[int]()
+}
@@ -0,0 +1,110 @@
+package matcha
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/version-1/go-matcha/internal/pointer"
+	"github.com/version-1/go-matcha/matcher"
+)
+
+// uuidV7At returns a v7 UUID whose timestamp is t.
+func uuidV7At(t time.Time) uuid.UUID {
+	u := uuid.Must(uuid.NewV7())
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(t.UnixMilli()))
+	copy(u[:6], ms[2:])
+	return u
+}
+
+func TestUUIDEqual(t *testing.T) {
+	v4 := uuid.New()
+	v7 := uuid.Must(uuid.NewV7())
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"uuid", matcher.BeUUID(), v4, true},
+		{"uuid with nil", matcher.BeUUID(), uuid.Nil, false},
+		{"uuid with string", matcher.BeUUID(), v4.String(), false},
+		{"version 4", matcher.BeUUID().Version(4), v4, true},
+		{"version 4 with v7", matcher.BeUUID().Version(4), v7, false},
+		{"version 4 or 7", matcher.BeUUID().Version(4, 7), v7, true},
+		{"variant", matcher.BeUUID().Variant(uuid.RFC4122), v4, true},
+		{"variant with other variant", matcher.BeUUID().Variant(uuid.Microsoft), v4, false},
+		{"created within", matcher.BeUUID().Version(7).CreatedWithin(time.Minute), v7, true},
+		{"created within with old uuid", matcher.BeUUID().CreatedWithin(time.Minute), uuidV7At(time.Now().Add(-time.Hour)), false},
+		{"created within with future uuid", matcher.BeUUID().CreatedWithin(time.Minute), uuidV7At(time.Now().Add(time.Hour)), false},
+		{"created within with v4", matcher.BeUUID().CreatedWithin(time.Minute), v4, false},
+		{"version ref", matcher.BeUUID().Version(4).Pointer(), &v4, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestUUIDStringEqual(t *testing.T) {
+	v4 := uuid.New()
+	v7 := uuid.Must(uuid.NewV7())
+
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"canonical", matcher.BeUUIDString(), v4.String(), true},
+		{"braced", matcher.BeUUIDString(), "{" + v4.String() + "}", true},
+		{"urn", matcher.BeUUIDString(), v4.URN(), true},
+		{"string pointer", matcher.BeUUIDString(), pointer.Ref(v4.String()), true},
+		{"nil string pointer", matcher.BeUUIDString(), (*string)(nil), false},
+		{"named string", matcher.BeUUIDString(), namedString(v4.String()), true},
+		{"raw bytes", matcher.BeUUIDString(), v4[:], true},
+		{"text bytes", matcher.BeUUIDString(), []byte(v4.String()), true},
+		{"short bytes", matcher.BeUUIDString(), v4[:15], false},
+		{"invalid", matcher.BeUUIDString(), "not-a-uuid", false},
+		{"empty", matcher.BeUUIDString(), "", false},
+		{"nil uuid", matcher.BeUUIDString(), uuid.Nil.String(), false},
+		{"nil uuid with allow zero", matcher.BeUUIDString().AllowZero(), uuid.Nil.String(), true},
+		{"uuid value", matcher.BeUUIDString(), v4, false},
+		{"version", matcher.BeUUIDString().Version(7), v7.String(), true},
+		{"version with other version", matcher.BeUUIDString().Version(7), v4.String(), false},
+		{"created within", matcher.BeUUIDString().CreatedWithin(time.Minute), v7.String(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestUUIDString(t *testing.T) {
+	tests := []struct {
+		m   matcher.Matcher
+		ans string
+	}{
+		{matcher.BeUUID(), "uuid"},
+		{matcher.BeUUIDString().AllowZero(), "uuidstring!zero"},
+		{matcher.BeUUID().Version(4, 7).Variant(uuid.RFC4122), "uuid(version 4|7, variant RFC4122)"},
+		{matcher.BeUUID().CreatedWithin(time.Hour), "uuid(created within 1h0m0s)"},
+	}
+
+	for _, tt := range tests {
+		if s := tt.m.(interface{ String() string }).String(); s != tt.ans {
+			t.Errorf("String should return %s, got %s", tt.ans, s)
+		}
+	}
+}
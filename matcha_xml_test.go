@@ -0,0 +1,144 @@
+package matcha
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/version-1/go-matcha/matcher"
+)
+
+const orderXML = `<?xml version="1.0"?>
+<order id="42" xmlns="urn:example:order">
+  <customer>Alice</customer>
+  <item sku="A-1">Book</item>
+  <item sku="B-2">Pen</item>
+</order>`
+
+func orderNode(items ...matcher.XMLNode) matcher.XMLNode {
+	return matcher.XMLNode{
+		Name:  "order",
+		Attrs: map[string]any{"id": matcher.RegExp("^[0-9]+$")},
+		Children: append([]matcher.XMLNode{
+			{Name: "customer", Text: "Alice"},
+		}, items...),
+	}
+}
+
+func TestXMLOfEqual(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect any
+		target any
+		ans    bool
+	}{
+		{"match", matcher.XMLOf(orderNode(
+			matcher.XMLNode{Name: "item", Attrs: map[string]any{"sku": "A-1"}, Text: "Book"},
+			matcher.XMLNode{Name: "item", Attrs: map[string]any{"sku": "B-2"}, Text: matcher.BeString()},
+		)), orderXML, true},
+		{"bytes", matcher.XMLOf(matcher.XMLNode{Name: "order"}), []byte(orderXML), true},
+		{"unchecked children", matcher.XMLOf(matcher.XMLNode{Name: "order", Attrs: map[string]any{"id": "42"}}), orderXML, true},
+		{"other root", matcher.XMLOf(matcher.XMLNode{Name: "invoice"}), orderXML, false},
+		{"other attribute", matcher.XMLOf(matcher.XMLNode{Name: "order", Attrs: map[string]any{"id": "1"}}), orderXML, false},
+		{"missing attribute", matcher.XMLOf(matcher.XMLNode{Name: "order", Attrs: map[string]any{"status": "open"}}), orderXML, false},
+		{"other text", matcher.XMLOf(orderNode(
+			matcher.XMLNode{Name: "item", Text: "Book"},
+			matcher.XMLNode{Name: "item", Text: "Pencil"},
+		)), orderXML, false},
+		{"fewer children", matcher.XMLOf(orderNode(
+			matcher.XMLNode{Name: "item"},
+		)), orderXML, false},
+		{"invalid xml", matcher.XMLOf(matcher.XMLNode{Name: "order"}), "<order>", false},
+		{"empty", matcher.XMLOf(matcher.XMLNode{Name: "order"}), "", false},
+		{"not a string", matcher.XMLOf(matcher.XMLNode{Name: "order"}), 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Equal(tt.expect, tt.target) != tt.ans {
+				t.Errorf("Equal(%v, %v) should return %v", tt.expect, tt.target, tt.ans)
+			}
+		})
+	}
+}
+
+func TestXMLOfRecords(t *testing.T) {
+	m := matcher.XMLOf(orderNode(
+		matcher.XMLNode{Name: "item", Attrs: map[string]any{"sku": "A-2", "qty": "1"}},
+		matcher.XMLNode{Name: "item", Text: "Pencil"},
+		matcher.XMLNode{Name: "note"},
+	))
+	if Equal(m, orderXML) {
+		t.Fatal("Equal should return false")
+	}
+
+	ans := []struct {
+		path string
+		code matcher.RecordCode
+	}{
+		{"/order", matcher.RecordCodeUnmatchLength},
+		{"/order/item/0/@qty", matcher.RecordCodeNotFound},
+		{"/order/item/0/@sku", matcher.RecordCodeNotEqual},
+		{"/order/item/1/text", matcher.RecordCodeNotEqual},
+		{"/order/note", matcher.RecordCodeNotFound},
+	}
+
+	// leaves flattens the record tree into the records without children.
+	var leaves func(list []matcher.Record) []matcher.Record
+	leaves = func(list []matcher.Record) []matcher.Record {
+		res := []matcher.Record{}
+		for _, r := range list {
+			if len(r.Children) == 0 || r.Code == matcher.RecordCodeUnmatchLength {
+				res = append(res, r)
+			}
+			res = append(res, leaves(r.Children)...)
+		}
+		return res
+	}
+
+	records := leaves(Records(m))
+	if len(records) != len(ans) {
+		t.Fatalf("Length should be %d, got %d: %v", len(ans), len(records), records)
+	}
+
+	for i, r := range records {
+		if p := r.PathWith(matcher.JSONPointer); p != ans[i].path {
+			t.Errorf("path should be %s, got %s", ans[i].path, p)
+		}
+
+		if r.Code != ans[i].code {
+			t.Errorf("r.Code should be %s, got %s", ans[i].code, r.Code)
+		}
+	}
+
+	if p := records[1].PathWith(matcher.GoPath); p != ".order.item[0].@qty" {
+		t.Errorf("GoPath should be .order.item[0].@qty, got %s", p)
+	}
+
+	if s := Records(m)[0].String(); !strings.Contains(s, "Field ( order > item > 0 > @sku ) didn't match.") {
+		t.Errorf("String should render the nested records, got %q", s)
+	}
+}
+
+func TestXMLOfMessage(t *testing.T) {
+	m := matcher.XMLOf(orderNode(
+		matcher.XMLNode{Name: "item", Attrs: map[string]any{"sku": "A-2"}},
+		matcher.XMLNode{Name: "item", Text: "Pencil"},
+	))
+	if Equal(m, orderXML) {
+		t.Fatal("Equal should return false")
+	}
+
+	records := Records(m)
+	if len(records) != 1 || records[0].Code != matcher.RecordCodeNested {
+		t.Fatalf("records should be one nested record, got %v", records)
+	}
+
+	// order and the group of items matched themselves and only nest the
+	// records of the items.
+	ans := "    Field ( order > item > 0 ) didn't match.\n\n        expect: <item sku=\"A-2\">\n\n        got: <item sku=\"A-1\">\n\n" +
+		"        Field ( order > item > 0 > @sku ) didn't match.\n\n                expect: A-2\n\n                got: A-1\n\n" +
+		"    Field ( order > item > 1 > text() ) didn't match.\n\n        expect: Pencil\n\n        got: Pen"
+	if s := records[0].String(); s != ans {
+		t.Errorf("String should be %q, got %q", ans, s)
+	}
+}
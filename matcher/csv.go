@@ -0,0 +1,110 @@
+package matcher
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+)
+
+// CSVOf matches CSV documents, given as string, *string or []byte, whose
+// first record is header and whose other records match rows. Each record
+// is turned into a Row from column name to value, so rows is usually
+// SliceOf(StructOf(...)). A []any of map[string]any is matched in order
+// like SliceOf of StructOf. A nil header only requires a header record, and
+// a header with a repeated column name never matches.
+func CSVOf(header []string, rows any) Matcher {
+	if elements, ok := rows.([]any); ok {
+		list := make([]any, len(elements))
+		for i, e := range elements {
+			if row, ok := e.(map[string]any); ok {
+				e = StructOf(row)
+			}
+			list[i] = e
+		}
+		rows = SliceOf(list)
+	}
+
+	return &csvMatcher{header: header, rows: rows}
+}
+
+type csvMatcher struct {
+	header []string
+	rows   any
+	result
+}
+
+func (m csvMatcher) Title() string {
+	return "CSVMatcher got errors."
+}
+
+func (m *csvMatcher) Match(v any) bool {
+	m.records = nil
+
+	b, ok := bytesValue(v)
+	if !ok {
+		m.records = append(m.records, recordUnexpectedType(m, "string", v))
+		return false
+	}
+
+	all, err := csv.NewReader(bytes.NewReader(b)).ReadAll()
+	if err == nil && len(all) == 0 {
+		err = errors.New("no header record")
+	}
+	if err != nil {
+		m.records = append(m.records, recordParseError(m, "csv", v, err))
+		return false
+	}
+
+	header := all[0]
+	if unique := HaveUniqueElements(); !unique.Match(header) {
+		m.records = append(m.records, recordNotEqual(m, FieldSegment("header", ""), unique, header))
+		return false
+	}
+
+	if m.header != nil && !Equal(m.header, header) {
+		m.records = append(m.records, recordNotEqual(m, FieldSegment("header", ""), m.header, header))
+		return false
+	}
+
+	rows := make([]Row, 0, len(all)-1)
+	for _, record := range all[1:] {
+		row := make(Row, len(header))
+		for i, name := range header {
+			row[name] = record[i]
+		}
+		rows = append(rows, row)
+	}
+
+	if Equal(m.rows, rows) {
+		return true
+	}
+
+	if rs := Explain(m.rows, rows); len(rs) > 0 {
+		m.records = append(m.records, rs...)
+	} else {
+		m.records = append(m.records, recordNotEqual(m, PathSegment{}, m.rows, rows))
+	}
+
+	return false
+}
+
+func (m csvMatcher) Not() Matcher {
+	return Not(&m)
+}
+
+func (m csvMatcher) Pointer() Matcher {
+	return Ref(&m)
+}
+
+func (m csvMatcher) String() string {
+	return fmt.Sprintf("csv(%v)", m.header)
+}
+
+func (m csvMatcher) Describe() string {
+	if m.header == nil {
+		return "a CSV document with rows " + Describe(m.rows)
+	}
+
+	return fmt.Sprintf("a CSV document with header %v and rows %s", m.header, Describe(m.rows))
+}
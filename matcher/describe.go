@@ -0,0 +1,125 @@
+package matcher
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Describer is implemented by matchers that can tell in words which values
+// they match, e.g. "a non-zero int" or "not an email". Every built-in
+// matcher implements it.
+type Describer interface {
+	Describe() string
+}
+
+var _ Describer = &RefMatcher{}
+var _ Describer = &notMatcher{}
+var _ Describer = beZero{}
+var _ Describer = beAny{}
+var _ Describer = anyBool{}
+var _ Describer = anyInt{}
+var _ Describer = anyString{}
+var _ Describer = regExpMatcher{}
+var _ Describer = emailMatcher{}
+var _ Describer = anySlice{}
+var _ Describer = anyStruct{}
+var _ Describer = anyTime{}
+var _ Describer = anyUUID{}
+var _ Describer = sliceLenMatcher{}
+var _ Describer = &sliceOfMatcher{}
+var _ Describer = &structOfMatcher{}
+var _ Describer = beNil{}
+var _ Describer = &anyOfMatcher{}
+var _ Describer = &jsonObjectMatcher{}
+var _ Describer = &jsonArrayMatcher{}
+var _ Describer = jsonLiteral{}
+var _ Describer = &eachMatcher{}
+var _ Describer = &containElementMatcher{}
+var _ Describer = &containInOrderMatcher{}
+var _ Describer = &uniqueMatcher{}
+var _ Describer = &atMatcher{}
+var _ Describer = &typeMatcher{}
+var _ Describer = &enumMatcher[string]{}
+var _ Describer = timeCondMatcher{}
+var _ Describer = idMatcher{}
+var _ Describer = formatMatcher{}
+var _ Describer = &jwtMatcher{}
+var _ Describer = timeStringMatcher{}
+var _ Describer = durationMatcher{}
+var _ Describer = nullMatcher{}
+var _ Describer = &xmlMatcher{}
+var _ Describer = &csvMatcher{}
+
+// Describe renders an expectation for messages: the description of
+// Describers and the %v form of other values.
+func Describe(v any) string {
+	if d, ok := v.(Describer); ok {
+		return d.Describe()
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+// describeValue renders an expectation inside a description, quoting
+// strings so that they stand out from the surrounding words.
+func describeValue(v any) string {
+	switch vv := v.(type) {
+	case Describer:
+		return vv.Describe()
+	case string:
+		return fmt.Sprintf("%q", vv)
+	case nil:
+		return "nil"
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+func describeList(list []any) string {
+	s := make([]string, len(list))
+	for i, v := range list {
+		s[i] = describeValue(v)
+	}
+
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+func describeFields(fields map[string]any) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	s := make([]string, len(keys))
+	for i, k := range keys {
+		s[i] = fmt.Sprintf("%s: %s", k, describeValue(fields[k]))
+	}
+
+	return "{" + strings.Join(s, ", ") + "}"
+}
+
+// describeKind describes the kind matchers like BeInt, e.g. "a non-zero
+// int" or "an int or named int".
+func describeKind(kind string, o MatcherOptions) string {
+	s := kind
+	if o.AllowNamed {
+		s += " or named " + kind
+	}
+
+	if !o.AllowZero {
+		return "a non-zero " + s
+	}
+
+	return article(s)
+}
+
+// article prefixes s with "a" or "an" by its first letter.
+func article(s string) string {
+	if s != "" && strings.ContainsRune("aeiou", rune(s[0])) {
+		return "an " + s
+	}
+
+	return "a " + s
+}
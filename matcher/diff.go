@@ -0,0 +1,85 @@
+package matcher
+
+type diffOp int
+
+const (
+	diffKeep diffOp = iota
+	diffChange
+	diffRemove
+	diffInsert
+)
+
+// diffEdit is one step of an edit script turning the expectations into the
+// target. expect and actual are indexes into each side, -1 when absent. A
+// removal has the target index the expectation is missing before as actual,
+// so that every edit is located in the target.
+type diffEdit struct {
+	op     diffOp
+	expect int
+	actual int
+}
+
+// diffSlice computes an edit script from the longest common subsequence of
+// expects and the elements of vw, comparing them with equal. Removals and
+// insertions between two kept elements are paired up as changes.
+func diffSlice(expects []any, vw maySlice, equal func(expect, target any) bool) []diffEdit {
+	n, m := len(expects), vw.Length()
+
+	eq := make([][]bool, n)
+	for i := range eq {
+		eq[i] = make([]bool, m)
+		for j := range eq[i] {
+			eq[i][j] = equal(expects[i], vw.element(j))
+		}
+	}
+
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if eq[i][j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var edits []diffEdit
+	var removed, inserted []int
+	flush := func(j int) {
+		k := 0
+		for ; k < len(removed) && k < len(inserted); k++ {
+			edits = append(edits, diffEdit{op: diffChange, expect: removed[k], actual: inserted[k]})
+		}
+		for _, i := range removed[k:] {
+			edits = append(edits, diffEdit{op: diffRemove, expect: i, actual: j})
+		}
+		for _, j := range inserted[k:] {
+			edits = append(edits, diffEdit{op: diffInsert, expect: -1, actual: j})
+		}
+		removed, inserted = removed[:0], inserted[:0]
+	}
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && eq[i][j] && lcs[i][j] == lcs[i+1][j+1]+1:
+			flush(j)
+			edits = append(edits, diffEdit{op: diffKeep, expect: i, actual: j})
+			i++
+			j++
+		case j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, i)
+			i++
+		default:
+			inserted = append(inserted, j)
+			j++
+		}
+	}
+	flush(m)
+
+	return edits
+}
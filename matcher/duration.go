@@ -0,0 +1,113 @@
+package matcher
+
+import (
+	"fmt"
+	"time"
+)
+
+// BeDuration matches non-zero time.Duration values and strings that
+// time.ParseDuration accepts, such as "1m30s" from decoded config.
+func BeDuration() *durationMatcher {
+	return &durationMatcher{name: "duration"}
+}
+
+// BeDurationBetween matches durations from lo to hi inclusive.
+func BeDurationBetween(lo, hi time.Duration) *durationMatcher {
+	return &durationMatcher{
+		name:    fmt.Sprintf("duration(between %s and %s)", lo, hi),
+		desc:    fmt.Sprintf("a duration between %s and %s", lo, hi),
+		options: MatcherOptions{AllowZero: true},
+		check: func(d time.Duration) bool {
+			return lo <= d && d <= hi
+		},
+	}
+}
+
+// BeDurationApprox matches durations at most tolerance away from d.
+func BeDurationApprox(d, tolerance time.Duration) *durationMatcher {
+	return &durationMatcher{
+		name:    fmt.Sprintf("duration(%s ± %s)", d, tolerance),
+		desc:    fmt.Sprintf("a duration within %s of %s", tolerance, d),
+		options: MatcherOptions{AllowZero: true},
+		check: func(v time.Duration) bool {
+			diff := v - d
+			if diff < 0 {
+				diff = -diff
+			}
+			return diff <= tolerance
+		},
+	}
+}
+
+type durationMatcher struct {
+	name    string
+	desc    string
+	check   func(time.Duration) bool
+	options MatcherOptions
+}
+
+func (m durationMatcher) Title() string {
+	return "DurationMatcher got errors."
+}
+
+func (m durationMatcher) Match(v any) bool {
+	return len(m.Explain(v)) == 0
+}
+
+func (m durationMatcher) Explain(v any) []Record {
+	var d time.Duration
+	switch vv := v.(type) {
+	case time.Duration:
+		d = vv
+	default:
+		s, ok := stringValue(v)
+		if !ok {
+			return []Record{recordUnexpectedType(m, "time.Duration", v)}
+		}
+
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return []Record{recordParseError(m, "duration", v, err)}
+		}
+		d = parsed
+	}
+
+	if !m.options.AllowZero && d == 0 {
+		return []Record{recordZeroValue(m, v)}
+	}
+
+	if m.check != nil && !m.check(d) {
+		return []Record{recordMismatch(m, v)}
+	}
+
+	return nil
+}
+
+func (m durationMatcher) Not() Matcher {
+	return Not(m)
+}
+
+func (m durationMatcher) Pointer() Matcher {
+	return Ref(m)
+}
+
+func (m durationMatcher) AllowZero() Matcher {
+	m.options.AllowZero = true
+	return m
+}
+
+func (m durationMatcher) String() string {
+	if m.check != nil {
+		return m.name
+	}
+
+	return modifierString(m.name, m.options)
+}
+
+func (m durationMatcher) Describe() string {
+	if m.check != nil {
+		return m.desc
+	}
+
+	return describeKind("duration", m.options)
+}
@@ -0,0 +1,387 @@
+package matcher
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/version-1/go-matcha/matcher/slices"
+)
+
+// Each matches slices whose elements all match m.
+func Each(m any) Matcher {
+	return &eachMatcher{m: m, n: -1}
+}
+
+// eachMatcher restricts the length as well when n is not negative.
+type eachMatcher struct {
+	m any
+	n int
+	result
+}
+
+func (m eachMatcher) Title() string {
+	return "EachMatcher got errors."
+}
+
+func (m *eachMatcher) Match(v any) bool {
+	m.records = nil
+
+	vw, r, ok := checkSlice(m, v)
+	if !ok {
+		m.records = append(m.records, r)
+		return false
+	}
+
+	if m.n >= 0 && vw.Length() != m.n {
+		m.records = append(m.records, recordUnmatchLength(m, m.n, vw.Length()))
+		return false
+	}
+
+	for i := 0; i < vw.Length(); i++ {
+		ele := vw.element(i)
+		if !Equal(m.m, ele) {
+			m.records = append(m.records, recordNotEqual(m, IndexSegment(i), m.m, ele))
+		}
+	}
+
+	return len(m.records) == 0
+}
+
+func (m eachMatcher) Not() Matcher {
+	return Not(&m)
+}
+
+func (m eachMatcher) Pointer() Matcher {
+	return Ref(&m)
+}
+
+func (m eachMatcher) String() string {
+	if m.n < 0 {
+		return fmt.Sprintf("slice<%s>", m.m)
+	}
+
+	return fmt.Sprintf("slice<%s>[%d]", m.m, m.n)
+}
+
+func (m eachMatcher) Describe() string {
+	if m.n < 0 {
+		return "a slice whose elements are each " + describeValue(m.m)
+	}
+
+	return fmt.Sprintf("a slice of %d elements that are each %s", m.n, describeValue(m.m))
+}
+
+// ContainElement matches slices that have at least one element matching m.
+func ContainElement(m any) Matcher {
+	return &containElementMatcher{m: m}
+}
+
+type containElementMatcher struct {
+	m any
+	result
+}
+
+func (m containElementMatcher) Title() string {
+	return "ContainElementMatcher got errors."
+}
+
+func (m *containElementMatcher) Match(v any) bool {
+	m.records = nil
+
+	vw, r, ok := checkSlice(m, v)
+	if !ok {
+		m.records = append(m.records, r)
+		return false
+	}
+
+	for i := 0; i < vw.Length(); i++ {
+		if Equal(m.m, vw.element(i)) {
+			return true
+		}
+	}
+
+	m.records = append(m.records, recordNotFoundExpect(m, PathSegment{}, m.m))
+	return false
+}
+
+func (m containElementMatcher) Not() Matcher {
+	return Not(&m)
+}
+
+func (m containElementMatcher) Pointer() Matcher {
+	return Ref(&m)
+}
+
+func (m containElementMatcher) Describe() string {
+	return "a slice containing " + describeValue(m.m)
+}
+
+// ContainElements matches slices that contain a distinct element for every
+// expectation, in any order.
+func ContainElements(ms ...any) Matcher {
+	return SliceOf(ms, slices.WithPersistOrder(false), slices.WithContains(true))
+}
+
+// ContainInOrder matches slices that contain ms as a subsequence. Other
+// elements may appear between them.
+func ContainInOrder(ms ...any) Matcher {
+	return &containInOrderMatcher{ms: ms}
+}
+
+type containInOrderMatcher struct {
+	ms []any
+	result
+}
+
+func (m containInOrderMatcher) Title() string {
+	return "ContainInOrderMatcher got errors."
+}
+
+func (m *containInOrderMatcher) Match(v any) bool {
+	m.records = nil
+
+	vw, r, ok := checkSlice(m, v)
+	if !ok {
+		m.records = append(m.records, r)
+		return false
+	}
+
+	// matching each expectation with the earliest possible element never
+	// rules out a subsequence that exists.
+	j := 0
+	for i, e := range m.ms {
+		for j < vw.Length() && !Equal(e, vw.element(j)) {
+			j++
+		}
+
+		if j == vw.Length() {
+			m.records = append(m.records, recordNotFoundExpect(m, IndexSegment(i), e))
+			return false
+		}
+		j++
+	}
+
+	return true
+}
+
+func (m containInOrderMatcher) Not() Matcher {
+	return Not(&m)
+}
+
+func (m containInOrderMatcher) Pointer() Matcher {
+	return Ref(&m)
+}
+
+func (m containInOrderMatcher) Describe() string {
+	return fmt.Sprintf("a slice containing %s in order", describeList(m.ms))
+}
+
+// HaveUniqueElements matches slices without deeply equal elements.
+func HaveUniqueElements() Matcher {
+	return &uniqueMatcher{}
+}
+
+// HaveUniqueBy matches slices whose elements are unique by the key fn
+// returns for them.
+func HaveUniqueBy[T any, K comparable](fn func(T) K) Matcher {
+	return &uniqueMatcher{
+		by: func(v any) (any, bool) {
+			t, ok := v.(T)
+			if !ok {
+				return nil, false
+			}
+
+			return fn(t), true
+		},
+		byType: reflect.TypeOf((*T)(nil)).Elem(),
+	}
+}
+
+type uniqueMatcher struct {
+	by     func(v any) (any, bool)
+	byType reflect.Type
+	result
+}
+
+func (m uniqueMatcher) Title() string {
+	return "UniqueMatcher got errors."
+}
+
+func (m *uniqueMatcher) Match(v any) bool {
+	m.records = nil
+
+	vw, r, ok := checkSlice(m, v)
+	if !ok {
+		m.records = append(m.records, r)
+		return false
+	}
+
+	seen := map[any]int{}
+	var others []any
+	var otherIndexes []int
+	for i := 0; i < vw.Length(); i++ {
+		ele := vw.element(i)
+
+		key := ele
+		if m.by != nil {
+			k, ok := m.by(ele)
+			if !ok {
+				r := recordUnexpectedType(m, m.byType.String(), ele)
+				r.Key = strconv.Itoa(i)
+				r.Segment = IndexSegment(i)
+				m.records = append(m.records, r)
+				continue
+			}
+			key = k
+		}
+
+		if m.by != nil || isHashable(reflect.TypeOf(key)) {
+			if first, ok := seen[key]; ok {
+				m.records = append(m.records, recordDuplicate(m, IndexSegment(i), first, ele))
+				continue
+			}
+			seen[key] = i
+			continue
+		}
+
+		duplicate := false
+		for n, o := range others {
+			if reflect.DeepEqual(o, key) {
+				m.records = append(m.records, recordDuplicate(m, IndexSegment(i), otherIndexes[n], ele))
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			others = append(others, key)
+			otherIndexes = append(otherIndexes, i)
+		}
+	}
+
+	return len(m.records) == 0
+}
+
+func (m uniqueMatcher) Not() Matcher {
+	return Not(&m)
+}
+
+func (m uniqueMatcher) Pointer() Matcher {
+	return Ref(&m)
+}
+
+func (m uniqueMatcher) Describe() string {
+	if m.by != nil {
+		return fmt.Sprintf("a slice of %s unique by key", m.byType)
+	}
+
+	return "a slice without duplicates"
+}
+
+// isHashable reports whether values of t can be used as map keys without
+// panicking, which is not the case for interfaces holding slices.
+func isHashable(t reflect.Type) bool {
+	if t == nil {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Func, reflect.Interface:
+		return false
+	case reflect.Array:
+		return isHashable(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !isHashable(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+// At matches slices whose element at index i matches m. A negative index
+// counts from the end.
+func At(i int, m any) Matcher {
+	return &atMatcher{i: i, m: m}
+}
+
+func First(m any) Matcher {
+	return At(0, m)
+}
+
+func Last(m any) Matcher {
+	return At(-1, m)
+}
+
+type atMatcher struct {
+	i int
+	m any
+	result
+}
+
+func (m atMatcher) Title() string {
+	return "AtMatcher got errors."
+}
+
+func (m *atMatcher) Match(v any) bool {
+	m.records = nil
+
+	vw, r, ok := checkSlice(m, v)
+	if !ok {
+		m.records = append(m.records, r)
+		return false
+	}
+
+	i := m.i
+	if i < 0 {
+		i += vw.Length()
+	}
+
+	ele, ok := vw.Index(i)
+	if !ok {
+		m.records = append(m.records, recordNotFoundExpect(m, IndexSegment(m.i), m.m))
+		return false
+	}
+
+	if !Equal(m.m, ele) {
+		m.records = append(m.records, recordNotEqual(m, IndexSegment(i), m.m, ele))
+		return false
+	}
+
+	return true
+}
+
+func (m atMatcher) Not() Matcher {
+	return Not(&m)
+}
+
+func (m atMatcher) Pointer() Matcher {
+	return Ref(&m)
+}
+
+func (m atMatcher) Describe() string {
+	switch {
+	case m.i == -1:
+		return "a slice whose last element is " + describeValue(m.m)
+	case m.i < 0:
+		return fmt.Sprintf("a slice whose element %d from the end is %s", -m.i, describeValue(m.m))
+	default:
+		return fmt.Sprintf("a slice whose element %d is %s", m.i, describeValue(m.m))
+	}
+}
+
+func checkSlice(m Matcher, v any) (maySlice, Record, bool) {
+	if v == nil {
+		return maySlice{}, recordTargetIsNil(m, v), false
+	}
+
+	vw := MaySlice(v)
+	if !vw.IsSlice() {
+		return vw, recordUnexpectedType(m, "Slice", v), false
+	}
+
+	return vw, Record{}, true
+}
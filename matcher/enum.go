@@ -0,0 +1,67 @@
+package matcher
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Enum matches values of type T that are one of values, e.g.
+// Enum(StatusActive, StatusInactive) for a type Status string.
+func Enum[T comparable](values ...T) *enumMatcher[T] {
+	return &enumMatcher[T]{values: values}
+}
+
+type enumMatcher[T comparable] struct {
+	values []T
+	result
+}
+
+func (m enumMatcher[T]) Title() string {
+	return "EnumMatcher got errors."
+}
+
+func (m *enumMatcher[T]) Match(v any) bool {
+	m.records = nil
+
+	vv, ok := v.(T)
+	if !ok {
+		m.records = append(m.records, recordUnexpectedType(m, typeName(reflect.TypeFor[T]()), v))
+		return false
+	}
+
+	for _, e := range m.values {
+		if e == vv {
+			return true
+		}
+	}
+
+	m.records = append(m.records, recordNotAllowed(m, m.values, v))
+	return false
+}
+
+func (m enumMatcher[T]) Not() Matcher {
+	return Not(&m)
+}
+
+func (m enumMatcher[T]) Pointer() Matcher {
+	return Ref(&m)
+}
+
+func (m enumMatcher[T]) String() string {
+	s := make([]string, len(m.values))
+	for i, e := range m.values {
+		s[i] = fmt.Sprintf("%#v", e)
+	}
+
+	return fmt.Sprintf("enum(%s)", strings.Join(s, ", "))
+}
+
+func (m enumMatcher[T]) Describe() string {
+	s := make([]any, len(m.values))
+	for i, e := range m.values {
+		s[i] = e
+	}
+
+	return "one of " + describeList(s)
+}
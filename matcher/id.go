@@ -0,0 +1,286 @@
+package matcher
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Epochs of well known Snowflake ID schemes for BeSnowflake.
+var (
+	TwitterSnowflakeEpoch = time.UnixMilli(1288834974657)
+	DiscordSnowflakeEpoch = time.UnixMilli(1420070400000)
+)
+
+// BeULID matches ULIDs as 26 character Crockford base32 text, given as
+// string, *string, []byte or a type implementing encoding.TextMarshaler such
+// as ulid.ULID, or as their 16 raw bytes in a []byte.
+func BeULID() *idMatcher {
+	return &idMatcher{name: "ulid", desc: "ULID", kind: "string, []byte or encoding.TextMarshaler", decode: decodeULID}
+}
+
+// BeKSUID matches KSUIDs as 27 character base62 text, given as string,
+// *string, []byte or a type implementing encoding.TextMarshaler such as
+// ksuid.KSUID, or as their 20 raw bytes in a []byte.
+func BeKSUID() *idMatcher {
+	return &idMatcher{name: "ksuid", desc: "KSUID", kind: "string, []byte or encoding.TextMarshaler", decode: decodeKSUID}
+}
+
+// BeSnowflake matches positive 64 bit Snowflake IDs, given as integers or
+// decimal strings, whose timestamp counts milliseconds from epoch.
+func BeSnowflake(epoch time.Time) *idMatcher {
+	return &idMatcher{
+		name:  "snowflake",
+		desc:  "snowflake ID",
+		kind:  "integer or string",
+		epoch: epoch,
+		decode: func(v any) (time.Time, error) {
+			return decodeSnowflake(v, epoch)
+		},
+	}
+}
+
+// idMatcher matches identifiers that embed their creation time.
+type idMatcher struct {
+	name string
+	desc string
+	// kind lists the types decode accepts.
+	kind      string
+	decode    func(v any) (time.Time, error)
+	epoch     time.Time
+	options   MatcherOptions
+	timestamp any
+}
+
+func (m idMatcher) Title() string {
+	return "IDMatcher got errors."
+}
+
+func (m idMatcher) Match(v any) bool {
+	return len(m.Explain(v)) == 0
+}
+
+func (m idMatcher) Explain(v any) []Record {
+	if v == nil {
+		return []Record{recordTargetIsNil(m, v)}
+	}
+
+	if !m.options.AllowZero && isZero(v) {
+		return []Record{recordZeroValue(m, v)}
+	}
+
+	t, err := m.decode(v)
+	if errors.Is(err, errUnexpectedIDType) {
+		return []Record{recordUnexpectedType(m, m.kind, v)}
+	}
+
+	if err != nil {
+		return []Record{recordParseError(m, m.name, v, err)}
+	}
+
+	if m.timestamp != nil && !Equal(m.timestamp, t) {
+		return []Record{recordNotEqual(m, FieldSegment("timestamp", ""), m.timestamp, t)}
+	}
+
+	return nil
+}
+
+func (m idMatcher) Not() Matcher {
+	return Not(m)
+}
+
+func (m idMatcher) Pointer() Matcher {
+	return Ref(m)
+}
+
+func (m idMatcher) AllowZero() Matcher {
+	m.options.AllowZero = true
+	return m
+}
+
+// Timestamp matches the time embedded in the ID against expect, e.g.
+// BeULID().Timestamp(BeTimeWithin(time.Minute)).
+func (m idMatcher) Timestamp(expect any) *idMatcher {
+	m.timestamp = expect
+	return &m
+}
+
+func (m idMatcher) String() string {
+	conds := []string{}
+	if !m.epoch.IsZero() {
+		conds = append(conds, "epoch "+m.epoch.UTC().Format(time.RFC3339Nano))
+	}
+	if m.timestamp != nil {
+		conds = append(conds, "timestamp "+argString(m.timestamp))
+	}
+
+	name := m.name
+	if len(conds) > 0 {
+		name = fmt.Sprintf("%s(%s)", name, strings.Join(conds, ", "))
+	}
+
+	return modifierString(name, m.options)
+}
+
+func (m idMatcher) Describe() string {
+	s := describeKind(m.desc, m.options)
+	if m.timestamp != nil {
+		s += " with timestamp " + describeValue(m.timestamp)
+	}
+
+	return s
+}
+
+// idBytes returns v as raw bytes of length n or as text. Other types are
+// read through encoding.TextMarshaler, which ulid.ULID and ksuid.KSUID
+// implement with their canonical text. Byte arrays are never taken as raw
+// bytes, since a uuid.UUID or any other [16]byte looks just like a ULID.
+func idBytes(v any, n int) ([]byte, string, bool) {
+	if b, ok := v.([]byte); ok {
+		if len(b) == n {
+			return b, "", true
+		}
+		return nil, string(b), true
+	}
+
+	if s, ok := stringValue(v); ok {
+		return nil, s, true
+	}
+
+	tm, ok := v.(encoding.TextMarshaler)
+	if !ok {
+		return nil, "", false
+	}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil, "", false
+	}
+
+	text, err := tm.MarshalText()
+	if err != nil {
+		return nil, "", false
+	}
+
+	return nil, string(text), true
+}
+
+// errUnexpectedIDType is returned by the decoders for values of types IDs
+// are never given as.
+var errUnexpectedIDType = errors.New("unexpected type")
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func decodeULID(v any) (time.Time, error) {
+	b, s, ok := idBytes(v, 16)
+	if !ok {
+		return time.Time{}, errUnexpectedIDType
+	}
+
+	if b != nil {
+		ms := binary.BigEndian.Uint64(append([]byte{0, 0}, b[:6]...))
+		return time.UnixMilli(int64(ms)), nil
+	}
+
+	if len(s) != 26 {
+		return time.Time{}, fmt.Errorf("length is %d, expect 26", len(s))
+	}
+
+	// 26 characters carry 130 bits, so the first one may only use 3.
+	if s[0] > '7' {
+		return time.Time{}, fmt.Errorf("first character %q overflows 128 bits", s[0])
+	}
+
+	var ms int64
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(crockford, upper(s[i]))
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("invalid character %q", s[i])
+		}
+		if i < 10 {
+			ms = ms<<5 | int64(d)
+		}
+	}
+
+	return time.UnixMilli(ms), nil
+}
+
+const (
+	base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// ksuidEpoch is the start of KSUID timestamps, 2014-05-13T16:53:20Z.
+	ksuidEpoch = 1400000000
+)
+
+func decodeKSUID(v any) (time.Time, error) {
+	b, s, ok := idBytes(v, 20)
+	if !ok {
+		return time.Time{}, errUnexpectedIDType
+	}
+
+	if b == nil {
+		if len(s) != 27 {
+			return time.Time{}, fmt.Errorf("length is %d, expect 27", len(s))
+		}
+
+		n := new(big.Int)
+		for i := 0; i < len(s); i++ {
+			d := strings.IndexByte(base62, s[i])
+			if d < 0 {
+				return time.Time{}, fmt.Errorf("invalid character %q", s[i])
+			}
+			n.Mul(n, big.NewInt(62))
+			n.Add(n, big.NewInt(int64(d)))
+		}
+
+		if n.BitLen() > 160 {
+			return time.Time{}, errors.New("value overflows 160 bits")
+		}
+		b = n.FillBytes(make([]byte, 20))
+	}
+
+	return time.Unix(int64(binary.BigEndian.Uint32(b[:4]))+ksuidEpoch, 0), nil
+}
+
+func decodeSnowflake(v any, epoch time.Time) (time.Time, error) {
+	var id uint64
+	rv := reflect.ValueOf(v)
+	switch {
+	case isIntKind(rv.Kind()):
+		if rv.Int() <= 0 {
+			return time.Time{}, fmt.Errorf("%d is not positive", rv.Int())
+		}
+		id = uint64(rv.Int())
+	case isUintKind(rv.Kind()):
+		id = rv.Uint()
+	default:
+		s, ok := stringValue(v)
+		if !ok {
+			return time.Time{}, errUnexpectedIDType
+		}
+
+		n, err := strconv.ParseUint(s, 10, 63)
+		if err != nil {
+			return time.Time{}, err
+		}
+		id = n
+	}
+
+	if id == 0 || id>>63 != 0 {
+		return time.Time{}, fmt.Errorf("%d is not a positive 63 bit integer", id)
+	}
+
+	return epoch.Add(time.Duration(id>>22) * time.Millisecond), nil
+}
+
+func upper(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+
+	return c
+}
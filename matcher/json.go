@@ -0,0 +1,402 @@
+package matcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type PlaceholderFunc func(arg string) (Matcher, error)
+
+var placeholders = struct {
+	sync.RWMutex
+	m map[string]PlaceholderFunc
+}{
+	m: map[string]PlaceholderFunc{
+		"uuid":   func(string) (Matcher, error) { return BeUUID(), nil },
+		"time":   func(string) (Matcher, error) { return BeTime(), nil },
+		"any":    func(string) (Matcher, error) { return BeAny(), nil },
+		"email":  func(string) (Matcher, error) { return Email(), nil },
+		"regexp": newRegExp,
+	},
+}
+
+// RegisterPlaceholder makes "<<name>>" and "<<name:arg>>" available in JSON
+// expectations. fn is called for every occurrence, so it should return a new
+// matcher each time.
+func RegisterPlaceholder(name string, fn PlaceholderFunc) {
+	placeholders.Lock()
+	defer placeholders.Unlock()
+
+	placeholders.m[name] = fn
+}
+
+func lookupPlaceholder(s string) (Matcher, bool, error) {
+	if !strings.HasPrefix(s, "<<") || !strings.HasSuffix(s, ">>") || len(s) < 4 {
+		return nil, false, nil
+	}
+
+	name, arg, _ := strings.Cut(s[2:len(s)-2], ":")
+
+	placeholders.RLock()
+	fn, ok := placeholders.m[name]
+	placeholders.RUnlock()
+	if !ok {
+		return nil, false, fmt.Errorf("unknown placeholder %s", s)
+	}
+
+	m, err := fn(arg)
+	if err != nil {
+		return nil, false, fmt.Errorf("placeholder %s: %w", s, err)
+	}
+
+	return m, true, nil
+}
+
+// LoadJSONExpectation reads a JSON file and turns it into a matcher.
+// Placeholder strings such as "<<uuid>>" are replaced by the registered
+// matchers and objects are matched against maps or structs by JSON key.
+// An unregistered placeholder name is an error.
+func LoadJSONExpectation(path string) (Matcher, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseJSONExpectation(b)
+}
+
+func ParseJSONExpectation(b []byte) (Matcher, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	var raw any
+	if err := d.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	return jsonExpectation(raw)
+}
+
+func jsonExpectation(raw any) (Matcher, error) {
+	switch v := raw.(type) {
+	case map[string]any:
+		fields := map[string]Matcher{}
+		for k, vv := range v {
+			m, err := jsonExpectation(vv)
+			if err != nil {
+				return nil, err
+			}
+			fields[k] = m
+		}
+		return &jsonObjectMatcher{fields: fields}, nil
+	case []any:
+		elements := make([]Matcher, len(v))
+		for i, vv := range v {
+			m, err := jsonExpectation(vv)
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = m
+		}
+		return &jsonArrayMatcher{elements: elements}, nil
+	case string:
+		m, ok, err := lookupPlaceholder(v)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return m, nil
+		}
+	}
+
+	return &jsonLiteral{value: raw}, nil
+}
+
+type jsonObjectMatcher struct {
+	fields map[string]Matcher
+	result
+}
+
+func (m jsonObjectMatcher) Title() string {
+	return "JSONExpectation got errors."
+}
+
+func (m *jsonObjectMatcher) Match(v any) bool {
+	m.records = nil
+
+	v = indirect(v)
+	if v == nil {
+		m.records = append(m.records, recordTargetIsNil(m, v))
+		return false
+	}
+
+	keys := make([]string, 0, len(m.fields))
+	for k := range m.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		for _, k := range keys {
+			f := rv.MapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()))
+			if !f.IsValid() {
+				m.records = append(m.records, recordNotFound(m, MapKeySegment(k)))
+				continue
+			}
+			m.matchField(k, MapKeySegment(k), f.Interface())
+		}
+	case rv.Kind() == reflect.Struct:
+		for _, k := range keys {
+			f, sf, ok := jsonField(rv, k)
+			if !ok {
+				m.records = append(m.records, recordNotFound(m, FieldSegment(k, k)))
+				continue
+			}
+			m.matchField(k, FieldSegment(sf.Name, k), f.Interface())
+		}
+	default:
+		m.records = append(m.records, recordUnexpectedType(m, "Object", v))
+	}
+
+	return len(m.records) == 0
+}
+
+// matchField records a mismatch under the JSON key, while seg keeps the Go
+// field name for the path formatters.
+func (m *jsonObjectMatcher) matchField(key string, seg PathSegment, actual any) {
+	expect := m.fields[key]
+	if !expect.Match(indirect(actual)) {
+		r := recordNotEqual(m, seg, expect, actual)
+		r.Key = key
+		m.records = append(m.records, r)
+	}
+}
+
+func (m jsonObjectMatcher) Not() Matcher {
+	return Not(&m)
+}
+
+func (m jsonObjectMatcher) Pointer() Matcher {
+	return Ref(&m)
+}
+
+func (m jsonObjectMatcher) Describe() string {
+	fields := make(map[string]any, len(m.fields))
+	for k, f := range m.fields {
+		fields[k] = f
+	}
+
+	return "a JSON object of " + describeFields(fields)
+}
+
+func jsonField(v reflect.Value, key string) (reflect.Value, reflect.StructField, bool) {
+	var fold *reflect.StructField
+	for _, f := range exportedFields(v.Type()) {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		if name == key {
+			return jsonFieldValue(v, f)
+		}
+
+		if fold == nil && strings.EqualFold(name, key) {
+			ff := f
+			fold = &ff
+		}
+	}
+
+	if fold != nil {
+		return jsonFieldValue(v, *fold)
+	}
+
+	return reflect.Value{}, reflect.StructField{}, false
+}
+
+// jsonFieldValue returns the field f of v. A field promoted through a nil
+// embedded pointer is absent, as encoding/json leaves it out.
+func jsonFieldValue(v reflect.Value, f reflect.StructField) (reflect.Value, reflect.StructField, bool) {
+	fv, err := v.FieldByIndexErr(f.Index)
+	if err != nil {
+		return reflect.Value{}, reflect.StructField{}, false
+	}
+
+	return fv, f, true
+}
+
+type jsonArrayMatcher struct {
+	elements []Matcher
+	result
+}
+
+func (m jsonArrayMatcher) Title() string {
+	return "JSONExpectation got errors."
+}
+
+func (m *jsonArrayMatcher) Match(v any) bool {
+	m.records = nil
+
+	v = indirect(v)
+	if v == nil {
+		m.records = append(m.records, recordTargetIsNil(m, v))
+		return false
+	}
+
+	vw := MaySlice(v)
+	if !vw.IsSlice() {
+		m.records = append(m.records, recordUnexpectedType(m, "Array", v))
+		return false
+	}
+
+	if len(m.elements) != vw.Length() {
+		m.records = append(m.records, recordUnmatchLength(m, len(m.elements), vw.Length()))
+		return false
+	}
+
+	for i, expect := range m.elements {
+		ele, _ := vw.Index(i)
+		if !expect.Match(indirect(ele)) {
+			m.records = append(m.records, recordNotEqual(m, IndexSegment(i), expect, ele))
+		}
+	}
+
+	return len(m.records) == 0
+}
+
+func (m jsonArrayMatcher) Not() Matcher {
+	return Not(&m)
+}
+
+func (m jsonArrayMatcher) Pointer() Matcher {
+	return Ref(&m)
+}
+
+func (m jsonArrayMatcher) Describe() string {
+	elements := make([]any, len(m.elements))
+	for i, e := range m.elements {
+		elements[i] = e
+	}
+
+	return "a JSON array of " + describeList(elements)
+}
+
+// jsonLiteral compares plain JSON values by kind, so that a JSON number
+// matches any numeric type and a JSON string matches named string types.
+type jsonLiteral struct {
+	value any
+}
+
+func (m jsonLiteral) Title() string {
+	return "JSONMatcher got errors."
+}
+
+func (m jsonLiteral) String() string {
+	if m.value == nil {
+		return "null"
+	}
+
+	return fmt.Sprint(m.value)
+}
+
+func (m jsonLiteral) Describe() string {
+	return describeValue(m.value)
+}
+
+func (m jsonLiteral) Match(v any) bool {
+	return len(m.Explain(v)) == 0
+}
+
+func (m jsonLiteral) Explain(v any) []Record {
+	if !m.match(v) {
+		return []Record{recordMismatch(m, v)}
+	}
+
+	return nil
+}
+
+func (m jsonLiteral) match(v any) bool {
+	if m.value == nil {
+		return isNil(v)
+	}
+
+	v = indirect(v)
+	if v == nil {
+		return false
+	}
+
+	rv := reflect.ValueOf(v)
+	switch expect := m.value.(type) {
+	case string:
+		return rv.Kind() == reflect.String && rv.String() == expect
+	case bool:
+		return rv.Kind() == reflect.Bool && rv.Bool() == expect
+	case json.Number:
+		return jsonNumberEqual(expect, rv)
+	}
+
+	return false
+}
+
+var jsonNumberType = reflect.TypeOf(json.Number(""))
+
+func jsonNumberEqual(n json.Number, v reflect.Value) bool {
+	if v.Type() == jsonNumberType {
+		return n.String() == v.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := n.Int64()
+		return err == nil && i == v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(n.String(), 10, 64)
+		return err == nil && u == v.Uint()
+	case reflect.Float32:
+		f, err := n.Float64()
+		return err == nil && float32(f) == float32(v.Float())
+	case reflect.Float64:
+		f, err := n.Float64()
+		return err == nil && f == v.Float()
+	default:
+		return false
+	}
+}
+
+func (m jsonLiteral) Not() Matcher {
+	return Not(m)
+}
+
+func (m jsonLiteral) Pointer() Matcher {
+	return Ref(m)
+}
+
+// indirect dereferences pointers, giving nil for a nil pointer as JSON
+// encodes it as null.
+func indirect(v any) any {
+	if v == nil {
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	return rv.Interface()
+}
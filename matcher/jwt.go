@@ -0,0 +1,252 @@
+package matcher
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/version-1/go-matcha/matcher/jwts"
+)
+
+// JWT matches compact JWS tokens whose claims match claims. A
+// map[string]any expectation requires only the keys it lists, also in
+// nested map[string]any expectations, and compares them like EqualLoose,
+// other expectations are matched against the whole
+// claim set. The NumericDate claims exp, nbf and iat are turned into
+// time.Time, so that they can be matched with time matchers.
+func JWT(claims any, opts ...func(*jwts.MatcherOptions)) Matcher {
+	o := jwts.MatcherOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &jwtMatcher{claims: claims, options: o}
+}
+
+type jwtMatcher struct {
+	claims  any
+	options jwts.MatcherOptions
+	result
+}
+
+func (m jwtMatcher) Title() string {
+	return "JWTMatcher got errors."
+}
+
+func (m *jwtMatcher) Match(v any) bool {
+	m.records = nil
+
+	s, ok := stringValue(v)
+	if !ok {
+		m.records = append(m.records, recordUnexpectedType(m, "string", v))
+		return false
+	}
+
+	header, claims, err := decodeJWT(s)
+	if err != nil {
+		m.records = append(m.records, recordParseError(m, "jwt", v, err))
+		return false
+	}
+
+	if m.options.Key != nil {
+		if err := verifyJWT(s, header, m.options.Key); err != nil {
+			m.records = append(m.records, recordInvalidSignature(m, v, err))
+			return false
+		}
+	}
+
+	if m.options.Header != nil {
+		if rs := m.matchObject(m.options.Header, header); len(rs) > 0 {
+			r := recordNotEqual(m, FieldSegment("Header", "header"), m.options.Header, header)
+			r.SetChildren(rs)
+			m.records = append(m.records, r)
+		}
+	}
+
+	m.records = append(m.records, m.matchObject(m.claims, claims)...)
+
+	return len(m.records) == 0
+}
+
+func (m *jwtMatcher) matchObject(expect any, actual map[string]any) []Record {
+	fields, ok := expect.(map[string]any)
+	if !ok {
+		if !EqualLoose(expect, actual) {
+			return []Record{recordNotEqual(m, PathSegment{}, expect, actual)}
+		}
+		return nil
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := []Record{}
+	for _, k := range keys {
+		a, ok := actual[k]
+		if !ok {
+			res = append(res, recordNotFound(m, MapKeySegment(k)))
+			continue
+		}
+
+		if nested, ok := a.(map[string]any); ok {
+			if _, ok := fields[k].(map[string]any); ok {
+				if rs := m.matchObject(fields[k], nested); len(rs) > 0 {
+					r := recordNotEqual(m, MapKeySegment(k), fields[k], a)
+					r.SetChildren(rs)
+					res = append(res, r)
+				}
+				continue
+			}
+		}
+
+		if !EqualLoose(fields[k], a) {
+			res = append(res, recordNotEqual(m, MapKeySegment(k), fields[k], a))
+		}
+	}
+
+	return res
+}
+
+func (m jwtMatcher) Not() Matcher {
+	return Not(&m)
+}
+
+func (m jwtMatcher) Pointer() Matcher {
+	return Ref(&m)
+}
+
+func (m jwtMatcher) String() string {
+	args := []string{}
+	if m.claims != nil {
+		args = append(args, "claims "+argString(m.claims))
+	}
+	if m.options.Header != nil {
+		args = append(args, "header "+argString(m.options.Header))
+	}
+	if m.options.Key != nil {
+		args = append(args, fmt.Sprintf("key <%T>", m.options.Key))
+	}
+
+	if len(args) == 0 {
+		return "jwt"
+	}
+
+	return fmt.Sprintf("jwt(%s)", strings.Join(args, ", "))
+}
+
+func (m jwtMatcher) Describe() string {
+	switch claims := m.claims.(type) {
+	case nil:
+		return "a JWT"
+	case map[string]any:
+		return "a JWT with claims " + describeFields(claims)
+	default:
+		return "a JWT with claims " + describeValue(claims)
+	}
+}
+
+func decodeJWT(token string) (map[string]any, map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, fmt.Errorf("token has %d parts, expect 3", len(parts))
+	}
+
+	header := map[string]any{}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, nil, fmt.Errorf("header: %w", err)
+	}
+
+	claims := map[string]any{}
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, nil, fmt.Errorf("claims: %w", err)
+	}
+
+	for _, k := range []string{"exp", "nbf", "iat"} {
+		if n, ok := claims[k].(float64); ok {
+			sec, frac := math.Modf(n)
+			claims[k] = time.Unix(int64(sec), int64(frac*1e9))
+		}
+	}
+
+	return header, claims, nil
+}
+
+func decodeJWTPart(part string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+func verifyJWT(token string, header map[string]any, key any) error {
+	i := strings.LastIndex(token, ".")
+	input := token[:i]
+	sig, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(token[i+1:], "="))
+	if err != nil {
+		return fmt.Errorf("signature: %w", err)
+	}
+
+	if k, ok := key.(interface{ Public() crypto.PublicKey }); ok {
+		key = k.Public()
+	}
+
+	alg, _ := header["alg"].(string)
+	hash := sha256.Sum256([]byte(input))
+	switch alg {
+	case "HS256":
+		secret, ok := key.([]byte)
+		if !ok {
+			return fmt.Errorf("alg HS256 needs a []byte key, got %T", key)
+		}
+
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(input))
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return errors.New("signature does not match")
+		}
+	case "RS256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("alg RS256 needs an RSA key, got %T", key)
+		}
+
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hash[:], sig); err != nil {
+			return err
+		}
+	case "ES256":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || pub.Curve != elliptic.P256() {
+			return fmt.Errorf("alg ES256 needs an ECDSA P-256 key, got %T", key)
+		}
+
+		if len(sig) != 64 {
+			return fmt.Errorf("signature is %d bytes long, expect 64", len(sig))
+		}
+
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(pub, hash[:], r, s) {
+			return errors.New("signature does not match")
+		}
+	default:
+		return fmt.Errorf("unsupported alg %q", alg)
+	}
+
+	return nil
+}
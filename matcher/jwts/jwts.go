@@ -0,0 +1,23 @@
+package jwts
+
+type MatcherOptions struct {
+	Header any
+	Key    any
+}
+
+// WithHeader matches the JOSE header like the claims, e.g.
+// map[string]any{"alg": "RS256", "kid": matcher.BeString()}.
+func WithHeader(expect any) func(*MatcherOptions) {
+	return func(o *MatcherOptions) {
+		o.Header = expect
+	}
+}
+
+// WithKey verifies the signature with key, a []byte secret for HS256, an
+// RSA key for RS256 or an ECDSA P-256 key for ES256. Private keys are
+// accepted as well.
+func WithKey(key any) func(*MatcherOptions) {
+	return func(o *MatcherOptions) {
+		o.Key = key
+	}
+}
@@ -0,0 +1,221 @@
+package matcher
+
+import (
+	"math"
+	"reflect"
+)
+
+// EqualLoose is Equal with coercion: numbers compare by value across integer
+// and float kinds, named types compare with their underlying kinds and
+// strings compare with []byte. Coercion also applies to the elements of
+// literal slices, maps and structs and inside StructOf and SliceOf.
+func EqualLoose(expect, target any) bool {
+	return equal(expect, target, true)
+}
+
+// coercer is a matcher that can match with coercion regardless of its own
+// options, so that EqualLoose reaches nested expectations.
+type coercer interface {
+	matchCoerced(v any) bool
+}
+
+var _ coercer = &structOfMatcher{}
+var _ coercer = &sliceOfMatcher{}
+var _ coercer = &RefMatcher{}
+var _ coercer = &notMatcher{}
+
+func equal(expect, target any, coerce bool) bool {
+	if !coerce {
+		return Equal(expect, target)
+	}
+
+	switch e := expect.(type) {
+	case coercer:
+		return e.matchCoerced(target)
+	case Matcher:
+		return e.Match(target)
+	}
+
+	return looseEqual(reflect.ValueOf(expect), reflect.ValueOf(target))
+}
+
+func looseEqual(e, t reflect.Value) bool {
+	for e.Kind() == reflect.Interface {
+		e = e.Elem()
+	}
+	for t.Kind() == reflect.Interface {
+		t = t.Elem()
+	}
+
+	if !e.IsValid() || !t.IsValid() {
+		return e.IsValid() == t.IsValid()
+	}
+
+	if e.CanInterface() {
+		if m, ok := e.Interface().(Matcher); ok {
+			if !t.CanInterface() {
+				return false
+			}
+			return equal(m, t.Interface(), true)
+		}
+	}
+
+	ek, tk := e.Kind(), t.Kind()
+	switch {
+	case isNumberKind(ek) && isNumberKind(tk):
+		return numberEqual(e, t)
+	case ek == reflect.String && isBytes(t):
+		return e.String() == string(t.Bytes())
+	case isBytes(e) && tk == reflect.String:
+		return string(e.Bytes()) == t.String()
+	case ek != tk:
+		return false
+	}
+
+	switch ek {
+	case reflect.Bool:
+		return e.Bool() == t.Bool()
+	case reflect.String:
+		return e.String() == t.String()
+	case reflect.Complex64, reflect.Complex128:
+		return e.Complex() == t.Complex()
+	case reflect.Pointer:
+		if e.IsNil() || t.IsNil() {
+			return e.IsNil() == t.IsNil()
+		}
+		return looseEqual(e.Elem(), t.Elem())
+	case reflect.Slice, reflect.Array:
+		if e.Len() != t.Len() {
+			return false
+		}
+		for i := 0; i < e.Len(); i++ {
+			if !looseEqual(e.Index(i), t.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		return looseMapEqual(e, t)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		// not comparable through Interface for unexported fields, and not
+		// at all for funcs, so they compare by identity.
+		return e.Type() == t.Type() && e.Pointer() == t.Pointer()
+	case reflect.Struct:
+		if e.Type() != t.Type() && !t.Type().ConvertibleTo(e.Type()) {
+			return false
+		}
+		for i := 0; i < e.NumField(); i++ {
+			if !looseEqual(e.Field(i), t.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if e.Type() != t.Type() || !e.Type().Comparable() || !e.CanInterface() || !t.CanInterface() {
+		return false
+	}
+
+	return e.Interface() == t.Interface()
+}
+
+// looseMapEqual pairs up the keys of both maps with coercion as well, so
+// that map[string]any matches map[Status]int.
+func looseMapEqual(e, t reflect.Value) bool {
+	if e.Len() != t.Len() {
+		return false
+	}
+
+	tkeys := t.MapKeys()
+	used := make([]bool, len(tkeys))
+	iter := e.MapRange()
+	for iter.Next() {
+		found := false
+		for i, k := range tkeys {
+			if used[i] || !looseEqual(iter.Key(), k) {
+				continue
+			}
+			if !looseEqual(iter.Value(), t.MapIndex(k)) {
+				return false
+			}
+			used[i] = true
+			found = true
+			break
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || k == reflect.Float32 || k == reflect.Float64
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUintKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+func isBytes(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8
+}
+
+// numberEqual compares two numbers by value without converting either side
+// to a type that cannot represent the other, e.g. uint64(math.MaxUint64)
+// never equals int64(-1) and 1.5 never equals 1.
+func numberEqual(e, t reflect.Value) bool {
+	ek, tk := e.Kind(), t.Kind()
+	switch {
+	case isIntKind(ek) && isIntKind(tk):
+		return e.Int() == t.Int()
+	case isUintKind(ek) && isUintKind(tk):
+		return e.Uint() == t.Uint()
+	case isIntKind(ek) && isUintKind(tk):
+		return e.Int() >= 0 && uint64(e.Int()) == t.Uint()
+	case isUintKind(ek) && isIntKind(tk):
+		return t.Int() >= 0 && e.Uint() == uint64(t.Int())
+	case isIntKind(tk):
+		return floatIntEqual(e.Float(), t.Int())
+	case isUintKind(tk):
+		return floatUintEqual(e.Float(), t.Uint())
+	case isIntKind(ek):
+		return floatIntEqual(t.Float(), e.Int())
+	case isUintKind(ek):
+		return floatUintEqual(t.Float(), e.Uint())
+	default:
+		return e.Float() == t.Float()
+	}
+}
+
+func floatIntEqual(f float64, i int64) bool {
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return false
+	}
+
+	return int64(f) == i
+}
+
+func floatUintEqual(f float64, u uint64) bool {
+	if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+		return false
+	}
+
+	return uint64(f) == u
+}
@@ -4,26 +4,68 @@ import (
 	"reflect"
 )
 
-func Equal(expect, target any) bool {
+// Equal reports whether target matches expect, which is a matcher or a
+// value compared by type and value.
+func Equal(expect, target any, opts ...func(*EqualOptions)) bool {
+	if len(opts) > 0 {
+		o := EqualOptions{}
+		for _, opt := range opts {
+			opt(&o)
+		}
+
+		if o.UnwrapValuers {
+			if value, ok := unwrapValuer(expect, target); ok {
+				return EqualLoose(expect, value)
+			}
+		}
+	}
+
 	if expect == nil {
 		return target == nil
 	}
 
-	v, ok := expect.(Matcher)
-	if ok {
-		return v.Match(target)
+	switch e := expect.(type) {
+	case Matcher:
+		return e.Match(target)
+	case string:
+		t, ok := target.(string)
+		return ok && e == t
+	case int:
+		t, ok := target.(int)
+		return ok && e == t
+	case bool:
+		t, ok := target.(bool)
+		return ok && e == t
 	}
 
 	r := reflect.TypeOf(expect)
-	if r.Kind() == reflect.Slice || r.Kind() == reflect.Array {
+	if r.Kind() == reflect.Slice || r.Kind() == reflect.Array || r.Kind() == reflect.Map {
 		return reflect.DeepEqual(expect, target)
 	}
 
 	return expect == target
 }
 
+type EqualOptions struct {
+	UnwrapValuers bool
+}
+
+// WithUnwrapValuers makes Equal compare a plain expectation with the value
+// of a driver.Valuer target like EqualLoose, e.g. "Alice" with
+// sql.NullString{String: "Alice", Valid: true} and nil with an invalid one.
+// It applies to the target itself, StructOf and SliceOf have options of the
+// same name for fields and elements.
+func WithUnwrapValuers() func(*EqualOptions) {
+	return func(o *EqualOptions) {
+		o.UnwrapValuers = true
+	}
+}
+
 type MatcherOptions struct {
 	AllowZero bool
+	// AllowNamed matches named types by their underlying kind, e.g. a
+	// type Status string for BeString.
+	AllowNamed bool
 }
 
 func IsMatcher(v any) bool {
@@ -49,3 +91,24 @@ var _ Matcher = anyStruct{}
 var _ Matcher = sliceLenMatcher{}
 var _ Matcher = &sliceOfMatcher{}
 var _ Matcher = &structOfMatcher{}
+var _ Matcher = beNil{}
+var _ Matcher = &anyOfMatcher{}
+var _ Matcher = &jsonObjectMatcher{}
+var _ Matcher = &jsonArrayMatcher{}
+var _ Matcher = jsonLiteral{}
+var _ Matcher = &eachMatcher{}
+var _ Matcher = &containElementMatcher{}
+var _ Matcher = &containInOrderMatcher{}
+var _ Matcher = &uniqueMatcher{}
+var _ Matcher = &atMatcher{}
+var _ Matcher = &typeMatcher{}
+var _ Matcher = &enumMatcher[string]{}
+var _ Matcher = timeCondMatcher{}
+var _ Matcher = idMatcher{}
+var _ Matcher = formatMatcher{}
+var _ Matcher = &jwtMatcher{}
+var _ Matcher = timeStringMatcher{}
+var _ Matcher = durationMatcher{}
+var _ Matcher = nullMatcher{}
+var _ Matcher = &xmlMatcher{}
+var _ Matcher = &csvMatcher{}
@@ -1,7 +1,9 @@
 package matcher
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 )
 
 func BeAny() *beAny {
@@ -12,12 +14,16 @@ type beAny struct {
 	options MatcherOptions
 }
 
+func (m beAny) Title() string {
+	return "AnyMatcher got errors."
+}
+
 func (m beAny) Match(v any) bool {
-	if !m.options.AllowZero && isZero(v) {
-		return false
-	}
+	return len(m.Explain(v)) == 0
+}
 
-	return true
+func (m beAny) Explain(v any) []Record {
+	return explainKind(m, "", v, true, m.options)
 }
 
 func (m beAny) Not() Matcher {
@@ -33,14 +39,38 @@ func (m beAny) AllowZero() Matcher {
 	return m
 }
 
+func (m beAny) String() string {
+	return modifierString("any", m.options)
+}
+
+func (m beAny) Describe() string {
+	if m.options.AllowZero {
+		return "any value"
+	}
+
+	return "any non-zero value"
+}
+
 func BeZero() *beZero {
 	return &beZero{}
 }
 
 type beZero struct{}
 
+func (b beZero) Title() string {
+	return "ZeroMatcher got errors."
+}
+
 func (b beZero) Match(v any) bool {
-	return isZero(v)
+	return len(b.Explain(v)) == 0
+}
+
+func (b beZero) Explain(v any) []Record {
+	if !isZero(v) {
+		return []Record{recordMismatch(b, v)}
+	}
+
+	return nil
 }
 
 func (b beZero) Not() Matcher {
@@ -51,12 +81,41 @@ func (b beZero) Pointer() Matcher {
 	return Ref(b)
 }
 
+func (b beZero) String() string {
+	return "zero"
+}
+
+func (b beZero) Describe() string {
+	return "a zero value"
+}
+
 type notMatcher struct {
 	m Matcher
 }
 
+func (m notMatcher) Title() string {
+	return "NotMatcher got errors."
+}
+
 func (m notMatcher) Match(v any) bool {
-	return !m.m.Match(v)
+	return len(m.Explain(v)) == 0
+}
+
+func (m notMatcher) matchCoerced(v any) bool {
+	return len(m.explain(v, equal(m.m, v, true))) == 0
+}
+
+func (m notMatcher) Explain(v any) []Record {
+	return m.explain(v, m.m.Match(v))
+}
+
+// explain records that the inner matcher matched v, which fails m.
+func (m notMatcher) explain(v any, matched bool) []Record {
+	if matched {
+		return []Record{recordUnexpectedMatch(m, m.m, v)}
+	}
+
+	return nil
 }
 
 func (m notMatcher) Pointer() Matcher {
@@ -67,6 +126,14 @@ func (m notMatcher) Not() Matcher {
 	return Not(m)
 }
 
+func (m notMatcher) String() string {
+	return fmt.Sprintf("not(%s)", m.m)
+}
+
+func (m notMatcher) Describe() string {
+	return "not " + Describe(m.m)
+}
+
 func Not(m Matcher) Matcher {
 	return &notMatcher{m: m}
 }
@@ -76,39 +143,58 @@ type RefMatcher struct {
 }
 
 func (r RefMatcher) Title() string {
-	v, ok := r.m.(Recorder)
-	if ok {
+	if v, ok := r.m.(Recorder); ok {
+		return v.Title()
+	}
+	if v, ok := r.m.(Explainer); ok {
 		return v.Title()
 	}
 
-	return ""
+	return "RefMatcher got errors."
 }
 
-func (r RefMatcher) Records() []Record {
-	v, ok := r.m.(Recorder)
-	if ok {
-		return v.Records()
-	}
+func (r RefMatcher) Match(v any) bool {
+	return len(r.Explain(v)) == 0
+}
 
-	return []Record{}
+func (r RefMatcher) matchCoerced(v any) bool {
+	return len(r.explain(v, func(e any) bool { return equal(r.m, e, true) })) == 0
 }
 
-func (r RefMatcher) Match(v any) bool {
+func (r RefMatcher) Explain(v any) []Record {
+	return r.explain(v, r.m.Match)
+}
+
+func (r RefMatcher) explain(v any, match func(any) bool) []Record {
 	if v == nil {
-		return r.m.Match(nil)
+		return r.explainElem(nil, match)
 	}
 
 	vv := reflect.ValueOf(v)
 	if vv.Kind() != reflect.Ptr {
-		return false
+		return []Record{recordNotPointer(r, v)}
 	}
 
 	e := vv.Elem()
 	if !e.IsValid() {
-		return r.m.Match(nil)
+		return r.explainElem(nil, match)
+	}
+
+	return r.explainElem(e.Interface(), match)
+}
+
+// explainElem matches the element v points to, taking over the records of
+// the inner matcher as they are since the pointer adds no path segment.
+func (r RefMatcher) explainElem(e any, match func(any) bool) []Record {
+	if match(e) {
+		return nil
 	}
 
-	return r.m.Match(e.Interface())
+	if rs := Explain(r.m, e); len(rs) > 0 {
+		return rs
+	}
+
+	return []Record{recordNotEqual(r, PathSegment{}, r.m, e)}
 }
 
 func (r RefMatcher) Not() Matcher {
@@ -119,6 +205,19 @@ func (r RefMatcher) Pointer() Matcher {
 	return Ref(r)
 }
 
+func (r RefMatcher) String() string {
+	switch r.m.(type) {
+	case anyOfMatcher, *anyOfMatcher:
+		return fmt.Sprintf("ptr(%s)", r.m)
+	}
+
+	return fmt.Sprintf("*%s", r.m)
+}
+
+func (r RefMatcher) Describe() string {
+	return "a pointer to " + Describe(r.m)
+}
+
 func Ref(m Matcher) Matcher {
 	return &RefMatcher{m: m}
 }
@@ -131,3 +230,108 @@ func ExtractIfPossible(mayRef any) any {
 
 	return mayRef
 }
+
+func BeNil() *beNil {
+	return &beNil{}
+}
+
+// beNil matches nil and typed nil values such as (*T)(nil).
+type beNil struct{}
+
+func (b beNil) Title() string {
+	return "NilMatcher got errors."
+}
+
+func (b beNil) Match(v any) bool {
+	return len(b.Explain(v)) == 0
+}
+
+func (b beNil) Explain(v any) []Record {
+	if !isNil(v) {
+		return []Record{recordMismatch(b, v)}
+	}
+
+	return nil
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	vv := reflect.ValueOf(v)
+	switch vv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return vv.IsNil()
+	default:
+		return false
+	}
+}
+
+func (b beNil) Not() Matcher {
+	return Not(b)
+}
+
+func (b beNil) Pointer() Matcher {
+	return Ref(b)
+}
+
+func (b beNil) String() string {
+	return "nil"
+}
+
+func (b beNil) Describe() string {
+	return "nil"
+}
+
+type anyOfMatcher struct {
+	ms []Matcher
+}
+
+func (m anyOfMatcher) Title() string {
+	return "AnyOfMatcher got errors."
+}
+
+func AnyOf(ms ...Matcher) Matcher {
+	return &anyOfMatcher{ms: ms}
+}
+
+func (m anyOfMatcher) Match(v any) bool {
+	return len(m.Explain(v)) == 0
+}
+
+func (m anyOfMatcher) Explain(v any) []Record {
+	for _, mm := range m.ms {
+		if mm.Match(v) {
+			return nil
+		}
+	}
+
+	return []Record{recordMismatch(m, v)}
+}
+
+func (m anyOfMatcher) Not() Matcher {
+	return Not(m)
+}
+
+func (m anyOfMatcher) Pointer() Matcher {
+	return Ref(m)
+}
+
+func (m anyOfMatcher) String() string {
+	s := make([]string, len(m.ms))
+	for i, mm := range m.ms {
+		s[i] = fmt.Sprint(mm)
+	}
+
+	return strings.Join(s, "|")
+}
+
+func (m anyOfMatcher) Describe() string {
+	s := make([]string, len(m.ms))
+	for i, mm := range m.ms {
+		s[i] = Describe(mm)
+	}
+
+	return strings.Join(s, " or ")
+}
@@ -0,0 +1,139 @@
+package matcher
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+// NullOf matches valid database/sql null values, such as sql.NullString or
+// sql.Null[T], whose value matches inner. The value is compared like
+// EqualLoose, so that NullOf(42) matches sql.NullInt64{Int64: 42, Valid:
+// true}.
+func NullOf(inner any) *nullMatcher {
+	return &nullMatcher{inner: inner}
+}
+
+// BeNull matches invalid database/sql null values.
+func BeNull() *nullMatcher {
+	return &nullMatcher{null: true}
+}
+
+type nullMatcher struct {
+	inner any
+	null  bool
+}
+
+func (m nullMatcher) Title() string {
+	return "NullMatcher got errors."
+}
+
+func (m nullMatcher) Match(v any) bool {
+	return len(m.Explain(v)) == 0
+}
+
+func (m nullMatcher) Explain(v any) []Record {
+	value, field, valid, ok := nullValue(v)
+	if !ok {
+		return []Record{recordUnexpectedType(m, "driver.Valuer", v)}
+	}
+
+	if m.null != !valid {
+		return []Record{recordNotEqual(m, PathSegment{}, m.String(), v)}
+	}
+
+	if !m.null && !EqualLoose(m.inner, value) {
+		seg := PathSegment{}
+		if field != "" {
+			seg = FieldSegment(field, "")
+		}
+		return []Record{recordNotEqual(m, seg, m.inner, value)}
+	}
+
+	return nil
+}
+
+func (m nullMatcher) Not() Matcher {
+	return Not(m)
+}
+
+func (m nullMatcher) Pointer() Matcher {
+	return Ref(m)
+}
+
+func (m nullMatcher) String() string {
+	if m.null {
+		return "null"
+	}
+
+	return fmt.Sprintf("null(%s)", argString(m.inner))
+}
+
+func (m nullMatcher) Describe() string {
+	if m.null {
+		return "a null value"
+	}
+
+	return "a valid null value of " + describeValue(m.inner)
+}
+
+// nullValue unwraps a null value. Structs made of a Valid flag and a value,
+// like the sql.Null types, give their value as is, other driver.Valuer
+// values give the result of Value. field is the name of the value field of
+// such structs. Like database/sql, a nil pointer to a type with a value
+// receiver Value is null.
+func nullValue(v any) (value any, field string, valid bool, ok bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && rv.IsNil() && rv.Type().Elem().Implements(valuerType) {
+		return nil, "", false, true
+	}
+
+	if rv.Kind() == reflect.Struct && rv.NumField() == 2 {
+		f, found := rv.Type().FieldByName("Valid")
+		if found && f.Type.Kind() == reflect.Bool && f.IsExported() {
+			i := 1 - f.Index[0]
+			if vf := rv.Type().Field(i); vf.IsExported() {
+				return rv.Field(i).Interface(), vf.Name, rv.Field(f.Index[0]).Bool(), true
+			}
+		}
+	}
+
+	valuer, ok := v.(driver.Valuer)
+	if !ok {
+		return nil, "", false, false
+	}
+
+	value, err := valuer.Value()
+	if err != nil {
+		return nil, "", false, false
+	}
+
+	return value, "", value != nil, true
+}
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// unwrapValuer returns the value of a driver.Valuer target for expect,
+// unless expect is a driver.Valuer or matcher itself, and whether it did.
+// Equal, StructOf and SliceOf use it with WithUnwrapValuers and compare the
+// value like EqualLoose, as drivers pick the Go types of values.
+func unwrapValuer(expect, target any) (any, bool) {
+	switch expect.(type) {
+	case Matcher, driver.Valuer:
+		return target, false
+	}
+
+	if _, ok := target.(driver.Valuer); !ok {
+		return target, false
+	}
+
+	value, _, valid, ok := nullValue(target)
+	switch {
+	case !ok:
+		return target, false
+	case !valid:
+		return nil, true
+	default:
+		return value, true
+	}
+}
@@ -0,0 +1,904 @@
+package matcher
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/version-1/go-matcha/matcher/urls"
+)
+
+// ParseError reports where an expression given to Parse is malformed.
+// Column is 1-based and counts bytes.
+type ParseError struct {
+	Expr   string
+	Column int
+	Msg    string
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("matcher: parse error at column %d in %q: %s", e.Column, e.Expr, e.Msg)
+}
+
+// Parse turns a textual expression into a matcher.
+//
+//	any, int, string, bool, uuid, uuidstring, time, duration, struct, slice, zero, nil, email
+//	int!zero          allow zero value
+//	string!named      allow named types such as type Status string
+//	*int, ptr(int)    pointer
+//	not(email)        negation
+//	regexp(^a.*)      regular expression, also regexp("^a.*")
+//	slice[3]          slice length
+//	slice<uuid>[3]    every element matches, optionally with length
+//	int|nil           any of
+//
+// Matchers with arguments take them in parentheses, separated by commas:
+//
+//	uuid(version 4|7, variant RFC4122, created within 1m0s)
+//	ulid(timestamp time(within 1m0s)), ksuid, snowflake(epoch 2010-11-04T01:42:54.657Z)
+//	time(within 1m0s), time(after 2024-01-01T00:00:00Z), time(before ...)
+//	duration(between 1s and 1m0s), duration(1s ± 100ms)
+//	url(scheme https|http, host *.example.com), ip, ipv4, ipv6, cidr, hostname,
+//	semver, base64, hex, iso8601, mac, jwt
+//	timestring("2006-01-02", time(after 2024-01-01T00:00:00Z))
+//	null, null("Alice"), null(int)
+//	kind(int)
+//
+// Arguments that are expectations, such as those of null and timestamp,
+// are matchers or string, number and boolean literals. String returns this
+// syntax for every matcher Parse can build. Matchers and arguments made of
+// Go values, such as type(...), enum(...), the claims of jwt or a StructOf
+// given to NullOf, print forms that Parse rejects, e.g. <a struct of {...}>,
+// instead of forms it would read as a looser matcher.
+func Parse(expr string) (Matcher, error) {
+	p := &parser{expr: expr}
+
+	m, err := p.parseUnion()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q", p.peek())
+	}
+
+	return m, nil
+}
+
+func MustParse(expr string) Matcher {
+	m, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+type parser struct {
+	expr string
+	pos  int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return ParseError{Expr: p.expr, Column: p.pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.expr)
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.expr[p.pos]
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && p.expr[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *parser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.peek() != c {
+		return false
+	}
+
+	p.pos++
+	return true
+}
+
+func (p *parser) expect(c byte) error {
+	if !p.consume(c) {
+		if p.eof() {
+			return p.errorf("expected %q but reached end of expression", c)
+		}
+		return p.errorf("expected %q but got %q", c, p.peek())
+	}
+
+	return nil
+}
+
+func (p *parser) ident() string {
+	p.skipSpaces()
+	start := p.pos
+	for !p.eof() {
+		c := rune(p.expr[p.pos])
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' {
+			break
+		}
+		p.pos++
+	}
+
+	return p.expr[start:p.pos]
+}
+
+func (p *parser) parseUnion() (Matcher, error) {
+	m, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	ms := []Matcher{m}
+	for p.consume('|') {
+		m, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, m)
+	}
+
+	if len(ms) == 1 {
+		return ms[0], nil
+	}
+
+	return AnyOf(ms...), nil
+}
+
+func (p *parser) parseTerm() (Matcher, error) {
+	if p.consume('*') {
+		m, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		return m.Pointer(), nil
+	}
+
+	m, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.consume('!') {
+		start := p.pos
+		name := p.ident()
+		switch name {
+		case "zero":
+			z, ok := m.(zeroAllower)
+			if !ok {
+				p.pos = start
+				return nil, p.errorf("%s does not support !zero", m)
+			}
+			m = z.AllowZero()
+		case "named":
+			n, ok := m.(namedAllower)
+			if !ok {
+				p.pos = start
+				return nil, p.errorf("%s does not support !named", m)
+			}
+			m = n.AllowNamed()
+		default:
+			p.pos = start
+			return nil, p.errorf("unknown modifier %q", name)
+		}
+	}
+
+	return m, nil
+}
+
+type zeroAllower interface {
+	AllowZero() Matcher
+}
+
+type namedAllower interface {
+	AllowNamed() Matcher
+}
+
+func (p *parser) parsePrimary() (Matcher, error) {
+	p.skipSpaces()
+	start := p.pos
+	name := p.ident()
+
+	switch name {
+	case "":
+		if p.eof() {
+			return nil, p.errorf("expected matcher but reached end of expression")
+		}
+		return nil, p.errorf("expected matcher but got %q", p.peek())
+	case "any":
+		return BeAny(), nil
+	case "int":
+		return BeInt(), nil
+	case "string":
+		return BeString(), nil
+	case "bool":
+		return BeBool(), nil
+	case "uuid":
+		return p.parseUUID(BeUUID())
+	case "uuidstring":
+		return p.parseUUID(BeUUIDString())
+	case "ulid":
+		return p.parseID(BeULID())
+	case "ksuid":
+		return p.parseID(BeKSUID())
+	case "snowflake":
+		return p.parseSnowflake()
+	case "time":
+		return p.parseTime()
+	case "duration":
+		return p.parseDuration()
+	case "url":
+		return p.parseURL()
+	case "ip":
+		return BeIP(), nil
+	case "ipv4":
+		return BeIPv4(), nil
+	case "ipv6":
+		return BeIPv6(), nil
+	case "cidr":
+		return BeCIDR(), nil
+	case "hostname":
+		return BeHostname(), nil
+	case "semver":
+		return BeSemver(), nil
+	case "base64":
+		return BeBase64(), nil
+	case "hex":
+		return BeHex(), nil
+	case "iso8601":
+		return BeISO8601(), nil
+	case "mac":
+		return BeMACAddress(), nil
+	case "jwt":
+		return JWT(nil), nil
+	case "timestring":
+		return p.parseTimeString()
+	case "null":
+		return p.parseNull()
+	case "kind":
+		return p.parseKind()
+	case "struct":
+		return BeStruct(), nil
+	case "zero":
+		return BeZero(), nil
+	case "nil":
+		return BeNil(), nil
+	case "email":
+		return Email(), nil
+	case "not", "ptr":
+		if err := p.expect('('); err != nil {
+			return nil, err
+		}
+		m, err := p.parseUnion()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(')'); err != nil {
+			return nil, err
+		}
+		if name == "not" {
+			return Not(m), nil
+		}
+		return m.Pointer(), nil
+	case "regexp":
+		return p.parseRegExp()
+	case "slice":
+		return p.parseSlice()
+	default:
+		p.pos = start
+		return nil, p.errorf("unknown matcher %q", name)
+	}
+}
+
+// parseArgs parses an optional parenthesized list of "name value"
+// arguments, calling fn with each name. fn parses the value.
+func (p *parser) parseArgs(fn func(name string) error) error {
+	if !p.consume('(') {
+		return nil
+	}
+
+	for {
+		p.skipSpaces()
+		start := p.pos
+		name := p.ident()
+		if name == "" {
+			return p.errorf("expected argument")
+		}
+
+		if err := fn(name); err != nil {
+			return err
+		}
+
+		if p.pos == start+len(name) {
+			p.pos = start
+			return p.errorf("unknown argument %q", name)
+		}
+
+		if !p.consume(',') {
+			return p.expect(')')
+		}
+	}
+}
+
+// parseArg parses an expectation argument: a matcher expression or a
+// string, number or boolean literal.
+func (p *parser) parseArg() (any, error) {
+	p.skipSpaces()
+	switch c := p.peek(); {
+	case c == '"':
+		return p.quoted()
+	case c == '-' || c >= '0' && c <= '9':
+		start := p.pos
+		w := p.word()
+		if i, err := strconv.Atoi(w); err == nil {
+			return i, nil
+		}
+		if f, err := strconv.ParseFloat(w, 64); err == nil {
+			return f, nil
+		}
+		p.pos = start
+		return nil, p.errorf("invalid number %q", w)
+	}
+
+	start := p.pos
+	switch p.ident() {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	p.pos = start
+
+	return p.parseUnion()
+}
+
+func (p *parser) quoted() (string, error) {
+	p.skipSpaces()
+	lit, err := strconv.QuotedPrefix(p.expr[p.pos:])
+	if err != nil {
+		return "", p.errorf("invalid quoted string")
+	}
+	p.pos += len(lit)
+
+	s, _ := strconv.Unquote(lit)
+	return s, nil
+}
+
+// word reads up to the next space, comma, parenthesis or bar, e.g. a
+// duration or a time.
+func (p *parser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(" ,()|", rune(p.peek())) {
+		p.pos++
+	}
+
+	return p.expr[start:p.pos]
+}
+
+// words reads one or more words separated by "|".
+func (p *parser) words() ([]string, error) {
+	list := []string{}
+	for {
+		w := p.word()
+		if w == "" {
+			return nil, p.errorf("expected value")
+		}
+		list = append(list, w)
+
+		if !p.consume('|') {
+			return list, nil
+		}
+	}
+}
+
+func (p *parser) keyword(want string) error {
+	p.skipSpaces()
+	start := p.pos
+	if p.ident() != want {
+		p.pos = start
+		return p.errorf("expected %q", want)
+	}
+
+	return nil
+}
+
+func (p *parser) duration() (time.Duration, error) {
+	p.skipSpaces()
+	start := p.pos
+	d, err := time.ParseDuration(p.word())
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("invalid duration: %s", err)
+	}
+
+	return d, nil
+}
+
+func (p *parser) time() (time.Time, error) {
+	p.skipSpaces()
+	start := p.pos
+	t, err := time.Parse(time.RFC3339Nano, p.word())
+	if err != nil {
+		p.pos = start
+		return time.Time{}, p.errorf("invalid time: %s", err)
+	}
+
+	return t, nil
+}
+
+func (p *parser) parseUUID(m *anyUUID) (Matcher, error) {
+	err := p.parseArgs(func(name string) error {
+		switch name {
+		case "version":
+			p.skipSpaces()
+			start := p.pos
+			list, err := p.words()
+			if err != nil {
+				return err
+			}
+			versions := make([]uuid.Version, len(list))
+			for i, w := range list {
+				v, err := strconv.ParseUint(w, 10, 8)
+				if err != nil {
+					p.pos = start
+					return p.errorf("invalid version %q", w)
+				}
+				versions[i] = uuid.Version(v)
+			}
+			m = m.Version(versions...)
+		case "variant":
+			p.skipSpaces()
+			start := p.pos
+			list, err := p.words()
+			if err != nil {
+				return err
+			}
+			variants := make([]uuid.Variant, len(list))
+			for i, w := range list {
+				v, ok := uuidVariant(w)
+				if !ok {
+					p.pos = start
+					return p.errorf("unknown variant %q", w)
+				}
+				variants[i] = v
+			}
+			m = m.Variant(variants...)
+		case "created":
+			if err := p.keyword("within"); err != nil {
+				return err
+			}
+			d, err := p.duration()
+			if err != nil {
+				return err
+			}
+			m = m.CreatedWithin(d)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func uuidVariant(name string) (uuid.Variant, bool) {
+	for _, v := range []uuid.Variant{uuid.Invalid, uuid.RFC4122, uuid.Reserved, uuid.Microsoft, uuid.Future} {
+		if v.String() == name {
+			return v, true
+		}
+	}
+
+	return 0, false
+}
+
+func (p *parser) parseID(m *idMatcher) (Matcher, error) {
+	err := p.parseArgs(func(name string) error {
+		if name != "timestamp" {
+			return nil
+		}
+		v, err := p.parseArg()
+		if err != nil {
+			return err
+		}
+		m = m.Timestamp(v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (p *parser) parseSnowflake() (Matcher, error) {
+	var epoch *time.Time
+	var timestamp any
+	err := p.parseArgs(func(name string) error {
+		switch name {
+		case "epoch":
+			t, err := p.time()
+			if err != nil {
+				return err
+			}
+			epoch = &t
+		case "timestamp":
+			v, err := p.parseArg()
+			if err != nil {
+				return err
+			}
+			timestamp = v
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if epoch == nil {
+		return nil, p.errorf("snowflake requires an epoch")
+	}
+
+	m := BeSnowflake(*epoch)
+	if timestamp != nil {
+		m = m.Timestamp(timestamp)
+	}
+
+	return m, nil
+}
+
+func (p *parser) parseTime() (Matcher, error) {
+	if !p.consume('(') {
+		return BeTime(), nil
+	}
+
+	p.skipSpaces()
+	start := p.pos
+
+	var m Matcher
+	switch name := p.ident(); name {
+	case "within":
+		d, err := p.duration()
+		if err != nil {
+			return nil, err
+		}
+		m = BeTimeWithin(d)
+	case "after", "before":
+		t, err := p.time()
+		if err != nil {
+			return nil, err
+		}
+		if name == "after" {
+			m = BeTimeAfter(t)
+		} else {
+			m = BeTimeBefore(t)
+		}
+	default:
+		p.pos = start
+		return nil, p.errorf("expected within, after or before")
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (p *parser) parseDuration() (Matcher, error) {
+	if !p.consume('(') {
+		return BeDuration(), nil
+	}
+
+	p.skipSpaces()
+	start := p.pos
+
+	var m Matcher
+	if p.ident() == "between" {
+		lo, err := p.duration()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.keyword("and"); err != nil {
+			return nil, err
+		}
+		hi, err := p.duration()
+		if err != nil {
+			return nil, err
+		}
+		m = BeDurationBetween(lo, hi)
+	} else {
+		p.pos = start
+		d, err := p.duration()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !strings.HasPrefix(p.expr[p.pos:], "±") {
+			return nil, p.errorf("expected %q", "±")
+		}
+		p.pos += len("±")
+		tolerance, err := p.duration()
+		if err != nil {
+			return nil, err
+		}
+		m = BeDurationApprox(d, tolerance)
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (p *parser) parseURL() (Matcher, error) {
+	opts := []func(*urls.MatcherOptions){}
+	err := p.parseArgs(func(name string) error {
+		switch name {
+		case "scheme":
+			list, err := p.words()
+			if err != nil {
+				return err
+			}
+			opts = append(opts, urls.WithSchemes(list...))
+		case "host":
+			list, err := p.words()
+			if err != nil {
+				return err
+			}
+			opts = append(opts, urls.WithHosts(list...))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return BeURL(opts...), nil
+}
+
+func (p *parser) parseTimeString() (Matcher, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	layout, err := p.quoted()
+	if err != nil {
+		return nil, err
+	}
+
+	var inner Matcher
+	if p.consume(',') {
+		m, err := p.parseUnion()
+		if err != nil {
+			return nil, err
+		}
+		inner = m
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return BeTimeString(layout, inner), nil
+}
+
+func (p *parser) parseNull() (Matcher, error) {
+	if !p.consume('(') {
+		return BeNull(), nil
+	}
+
+	v, err := p.parseArg()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return NullOf(v), nil
+}
+
+func (p *parser) parseKind() (Matcher, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	start := p.pos
+	name := p.ident()
+
+	k, ok := kindByName(name)
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("unknown kind %q", name)
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return BeKind(k), nil
+}
+
+func kindByName(name string) (reflect.Kind, bool) {
+	for k := reflect.Bool; k <= reflect.UnsafePointer; k++ {
+		if k.String() == name {
+			return k, true
+		}
+	}
+
+	return reflect.Invalid, false
+}
+
+func (p *parser) parseRegExp() (Matcher, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	start := p.pos
+
+	var pattern string
+	if p.peek() == '"' {
+		lit, err := strconv.QuotedPrefix(p.expr[p.pos:])
+		if err != nil {
+			return nil, p.errorf("invalid quoted pattern")
+		}
+		pattern, _ = strconv.Unquote(lit)
+		p.pos += len(lit)
+	} else {
+		depth := 0
+	loop:
+		for ; !p.eof(); p.pos++ {
+			switch p.expr[p.pos] {
+			case '\\':
+				p.pos++
+			case '(':
+				depth++
+			case ')':
+				if depth == 0 {
+					break loop
+				}
+				depth--
+			}
+		}
+		if p.pos > len(p.expr) {
+			p.pos = len(p.expr)
+		}
+		pattern = p.expr[start:p.pos]
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	m, err := newRegExp(pattern)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid pattern: %s", err)
+	}
+
+	return m, nil
+}
+
+func (p *parser) parseSlice() (Matcher, error) {
+	var elem Matcher
+	if p.consume('<') {
+		m, err := p.parseUnion()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect('>'); err != nil {
+			return nil, err
+		}
+		elem = m
+	}
+
+	n := -1
+	if p.consume('[') {
+		p.skipSpaces()
+		start := p.pos
+		for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+			p.pos++
+		}
+		v, err := strconv.Atoi(p.expr[start:p.pos])
+		if err != nil {
+			p.pos = start
+			return nil, p.errorf("expected length")
+		}
+		if err := p.expect(']'); err != nil {
+			return nil, err
+		}
+		n = v
+	}
+
+	switch {
+	case elem != nil:
+		return &eachMatcher{m: elem, n: n}, nil
+	case n >= 0:
+		return SliceLen(n), nil
+	default:
+		return BeSlice(), nil
+	}
+}
+
+func modifierString(name string, o MatcherOptions) string {
+	if o.AllowZero {
+		name += "!zero"
+	}
+	if o.AllowNamed {
+		name += "!named"
+	}
+
+	return name
+}
+
+// argString renders an expectation argument the way parseArg reads it.
+// Other arguments are described in angle brackets, which parseArg rejects.
+func argString(v any) string {
+	switch vv := v.(type) {
+	case string:
+		return strconv.Quote(vv)
+	case int, bool:
+		return fmt.Sprint(vv)
+	case map[string]any:
+		return "<" + describeFields(vv) + ">"
+	case float64:
+		s := strconv.FormatFloat(vv, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eEIN") {
+			s += ".0"
+		}
+		return s
+	case Matcher:
+		if s, ok := vv.(fmt.Stringer); ok {
+			return s.String()
+		}
+	}
+
+	return "<" + describeValue(v) + ">"
+}
+
+func regExpString(pattern string) string {
+	depth := 0
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth < 0 {
+			break
+		}
+	}
+
+	if depth != 0 || strings.TrimSpace(pattern) != pattern || strings.HasPrefix(pattern, `"`) {
+		return fmt.Sprintf("regexp(%s)", strconv.Quote(pattern))
+	}
+
+	return fmt.Sprintf("regexp(%s)", pattern)
+}
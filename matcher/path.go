@@ -0,0 +1,185 @@
+package matcher
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+type SegmentKind int
+
+const (
+	SegmentNone SegmentKind = iota
+	SegmentField
+	SegmentIndex
+	SegmentMapKey
+	// SegmentPointer is a raw JSON Pointer reference token.
+	SegmentPointer
+	SegmentMethod
+)
+
+// PathSegment is one step from a record's parent to the record itself.
+type PathSegment struct {
+	Kind SegmentKind
+	// Name is the Go field or method name, the map key or the pointer token.
+	Name string
+	// JSONName is the name of a field in its JSON encoding.
+	JSONName string
+	Index    int
+}
+
+func FieldSegment(name, jsonName string) PathSegment {
+	if jsonName == "" {
+		jsonName = name
+	}
+
+	return PathSegment{Kind: SegmentField, Name: name, JSONName: jsonName}
+}
+
+func IndexSegment(i int) PathSegment {
+	return PathSegment{Kind: SegmentIndex, Name: strconv.Itoa(i), Index: i}
+}
+
+func MapKeySegment(key any) PathSegment {
+	return PathSegment{Kind: SegmentMapKey, Name: fmt.Sprint(key)}
+}
+
+func PointerSegment(token string) PathSegment {
+	return PathSegment{Kind: SegmentPointer, Name: token}
+}
+
+func MethodSegment(name string) PathSegment {
+	return PathSegment{Kind: SegmentMethod, Name: name}
+}
+
+func structFieldSegment(f reflect.StructField) PathSegment {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" {
+		name = ""
+	}
+
+	return FieldSegment(f.Name, name)
+}
+
+// PathFormatter renders the segments from the root record down to a record.
+type PathFormatter func(segments []PathSegment) string
+
+// DefaultPath joins segments with " > ", e.g. Posts > 2 > Title.
+func DefaultPath(segments []PathSegment) string {
+	s := make([]string, len(segments))
+	for i, seg := range segments {
+		if seg.Kind == SegmentMethod {
+			s[i] = seg.Name + "()"
+			continue
+		}
+		s[i] = seg.Name
+	}
+
+	return strings.Join(s, " > ")
+}
+
+// GoPath renders a Go selector expression, e.g. .Posts[2].Title.
+func GoPath(segments []PathSegment) string {
+	var b strings.Builder
+	for _, seg := range segments {
+		switch seg.Kind {
+		case SegmentIndex:
+			fmt.Fprintf(&b, "[%d]", seg.Index)
+		case SegmentMapKey, SegmentPointer:
+			fmt.Fprintf(&b, "[%q]", seg.Name)
+		case SegmentMethod:
+			fmt.Fprintf(&b, ".%s()", seg.Name)
+		default:
+			fmt.Fprintf(&b, ".%s", seg.Name)
+		}
+	}
+
+	return b.String()
+}
+
+// JSONPath renders a JSONPath expression with JSON field names, e.g.
+// $.posts[2].title.
+func JSONPath(segments []PathSegment) string {
+	var b strings.Builder
+	b.WriteString("$")
+	for _, seg := range segments {
+		name := seg.Name
+		if seg.Kind == SegmentField {
+			name = seg.JSONName
+		}
+
+		switch {
+		case seg.Kind == SegmentIndex:
+			fmt.Fprintf(&b, "[%d]", seg.Index)
+		case seg.Kind == SegmentMethod:
+			fmt.Fprintf(&b, ".%s()", name)
+		case isJSONPathIdent(name):
+			fmt.Fprintf(&b, ".%s", name)
+		default:
+			fmt.Fprintf(&b, "['%s']", strings.ReplaceAll(name, "'", `\'`))
+		}
+	}
+
+	return b.String()
+}
+
+func isJSONPathIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, c := range s {
+		if c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+			continue
+		}
+		if i > 0 && c >= '0' && c <= '9' {
+			continue
+		}
+		return false
+	}
+
+	return true
+}
+
+// JSONPointer renders an RFC 6901 JSON Pointer, e.g. /posts/2/title.
+func JSONPointer(segments []PathSegment) string {
+	r := strings.NewReplacer("~", "~0", "/", "~1")
+
+	var b strings.Builder
+	for _, seg := range segments {
+		name := seg.Name
+		if seg.Kind == SegmentField {
+			name = seg.JSONName
+		}
+		if seg.Kind != SegmentPointer {
+			name = r.Replace(name)
+		}
+
+		b.WriteString("/")
+		b.WriteString(name)
+	}
+
+	return b.String()
+}
+
+var pathFormatter atomic.Value
+
+func init() {
+	pathFormatter.Store(PathFormatter(DefaultPath))
+}
+
+// SetPathFormatter changes how Record.Path renders paths in every
+// assertion and returns the previous formatter.
+func SetPathFormatter(f PathFormatter) PathFormatter {
+	if f == nil {
+		f = DefaultPath
+	}
+
+	return pathFormatter.Swap(f).(PathFormatter)
+}
+
+func currentPathFormatter() PathFormatter {
+	return pathFormatter.Load().(PathFormatter)
+}
@@ -9,12 +9,16 @@ type anyInt struct {
 	options MatcherOptions
 }
 
+func (m anyInt) Title() string {
+	return "IntMatcher got errors."
+}
+
 func (m anyInt) Match(v any) bool {
-	if !m.options.AllowZero && isZero(v) {
-		return false
-	}
+	return len(m.Explain(v)) == 0
+}
 
-	return typeMatch[int](v)
+func (m anyInt) Explain(v any) []Record {
+	return explainKind(m, "int", v, kindMatch[int](v, m.options), m.options)
 }
 
 func (m anyInt) Not() Matcher {
@@ -30,8 +34,27 @@ func (m anyInt) AllowZero() Matcher {
 	return m
 }
 
+func (m anyInt) AllowNamed() Matcher {
+	m.options.AllowNamed = true
+	return m
+}
+
+func (m anyInt) String() string {
+	return modifierString("int", m.options)
+}
+
+func (m anyInt) Describe() string {
+	return describeKind("int", m.options)
+}
+
 // bool
-type anyBool struct{}
+type anyBool struct {
+	options MatcherOptions
+}
+
+func (e anyBool) Title() string {
+	return "BoolMatcher got errors."
+}
 
 func BeBool() *anyBool {
 	return &anyBool{}
@@ -39,7 +62,13 @@ func BeBool() *anyBool {
 
 // INFO: bool matcher allows zero by default
 func (e anyBool) Match(v any) bool {
-	return typeMatch[bool](v)
+	return len(e.Explain(v)) == 0
+}
+
+func (e anyBool) Explain(v any) []Record {
+	o := e.options
+	o.AllowZero = true
+	return explainKind(e, "bool", v, kindMatch[bool](v, o), o)
 }
 
 func (e anyBool) Not() Matcher {
@@ -49,3 +78,18 @@ func (e anyBool) Not() Matcher {
 func (e anyBool) Pointer() Matcher {
 	return Ref(e)
 }
+
+func (e anyBool) AllowNamed() Matcher {
+	e.options.AllowNamed = true
+	return e
+}
+
+func (e anyBool) String() string {
+	return modifierString("bool", e.options)
+}
+
+func (e anyBool) Describe() string {
+	o := e.options
+	o.AllowZero = true
+	return describeKind("bool", o)
+}
@@ -1,8 +1,6 @@
 package matcher
 
 import (
-	"fmt"
-	"reflect"
 	"strings"
 )
 
@@ -11,11 +9,16 @@ type Record struct {
 	Root     Matcher
 	Code     RecordCode
 	Key      string
+	Segment  PathSegment
 	Expect   any
 	Actual   any
 	Parent   *Record
 	Children []Record
-	depth    int
+	// Suggestions are names close to Key when Key does not exist.
+	Suggestions []string
+	// Err is why Actual could not be parsed.
+	Err   error
+	depth int
 }
 
 func (r *Record) SetChildren(list []Record) {
@@ -28,19 +31,39 @@ func (r *Record) SetChildren(list []Record) {
 		}
 		r.Children[i].Parent = r
 		r.Children[i].depth = r.depth + 1
+		if r.Code == RecordCodeNested {
+			// nested records render nothing themselves.
+			r.Children[i].depth = r.depth
+		}
+		// relink grandchildren so that their paths go through this record.
+		r.Children[i].SetChildren(r.Children[i].Children)
 	}
 }
 
-func (r Record) Path() string {
-	path := []string{}
-	n := r.Parent
-	for n != nil {
-		path = append([]string{n.Key}, path...)
-		n = n.Parent
+// Segments returns the path segments from the root record down to r.
+// Records built without a Segment fall back to their Key as a field name.
+func (r Record) Segments() []PathSegment {
+	path := []PathSegment{}
+	for n := &r; n != nil; n = n.Parent {
+		seg := n.Segment
+		if seg.Kind == SegmentNone {
+			if n.Key == "" {
+				continue
+			}
+			seg = FieldSegment(n.Key, "")
+		}
+		path = append([]PathSegment{seg}, path...)
 	}
-	path = append(path, r.Key)
 
-	return strings.Join(path, " > ")
+	return path
+}
+
+func (r Record) Path() string {
+	return r.PathWith(currentPathFormatter())
+}
+
+func (r Record) PathWith(f PathFormatter) string {
+	return f(r.Segments())
 }
 
 func (r Record) Error() string {
@@ -50,13 +73,12 @@ func (r Record) Error() string {
 var padding int = 4
 
 func isSliceOfMatcher(m Matcher) bool {
-	_, ok := m.(*sliceOfMatcher)
-	return ok
-}
-
-func isStructOfMatcher(m Matcher) bool {
-	_, ok := m.(*structOfMatcher)
-	return ok
+	switch m.(type) {
+	case *sliceOfMatcher, sliceLenMatcher, *sliceLenMatcher, *eachMatcher, *containElementMatcher, *containInOrderMatcher, *uniqueMatcher, *atMatcher:
+		return true
+	default:
+		return false
+	}
 }
 
 type recordPrinter struct {
@@ -64,80 +86,29 @@ type recordPrinter struct {
 }
 
 func (r Record) String() string {
-	indent := strings.Repeat(" ", (r.depth+1)*padding)
-	chIndent := strings.Repeat(indent, 2)
-	keyName := "Field"
-	isSliceMatcher := isSliceOfMatcher(r.Matcher)
-	if isSliceMatcher {
-		keyName = "Slice"
-	}
-
-	switch r.Code {
-	case RecordCodeTargetIsNil:
-		return fmt.Sprintf("%sTarget is nil. expect %T but got nil", indent, r.Matcher)
-	case RecordCodeUnmatchLength:
-		// TODO: diff fields and print
-		return fmt.Sprintf("%s%s length is unmatched. expect %d but got %d", indent, keyName, r.Expect, r.Actual)
-	case RecordCodeUnexpectedType:
-		return fmt.Sprintf("%sTarget is unexpected type. expect %s but got %T", indent, r.Expect, r.Actual)
-	case RecordCodeNotFound:
-		if isSliceMatcher {
-			return fmt.Sprintf("%sIndex: %s is not found.", indent, r.Path())
-		}
-		return fmt.Sprintf("%s%s is not found. field: %s", indent, keyName, r.Path())
-	case RecordCodeNotEqual:
-		v := ExtractIfPossible(r.Expect)
-		som, ok := v.(*structOfMatcher)
-		if ok {
-			msgfmt := "%sField ( %s ) didn't match.\n\n%sexpect: %#v\n\n%sgot: %#v"
-
-			msg := fmt.Sprintf(msgfmt, indent, r.Path(), chIndent, som.fields, chIndent, r.Actual)
-			msg += "\n\n"
-			for _, c := range r.Children {
-				msg += c.String()
-			}
-
-			return msg
-		}
-
-		slm, ok := v.(*sliceOfMatcher)
-		if ok {
-			msgfmt := "%sIndex ( %s ) didn't match.\n\n%sexpect: %v\n\n%sgot: %v"
-
-			msg := fmt.Sprintf(msgfmt, indent, r.Path(), chIndent, slm.elements, chIndent, r.Actual)
-			msg += "\n\n"
-			for _, c := range r.Children {
-				msg += c.String()
-			}
-
-			return msg
-		}
-
-		expectType := reflect.TypeOf(r.Expect)
-		if isSlice(expectType) {
-			msgfmt := "%sIndex ( %s ) didn't match.\n\n%sexpect: %v\n\n%sgot: %v"
-
-			msg := fmt.Sprintf(msgfmt, indent, r.Path(), chIndent, r.Expect, chIndent, r.Actual)
-			msg += "\n\n"
-			for _, c := range r.Children {
-				msg += c.String()
-			}
+	return r.Format(currentPathFormatter())
+}
 
-			return msg
-		}
+// Format renders the record like String, with paths rendered by f, using
+// the formatter registered for its code.
+func (r Record) Format(f PathFormatter) string {
+	return lookupRecordFormatter(r.Code)(r, f)
+}
 
-		if r.Root != nil {
-			if isSliceOfMatcher(r.Root) {
-				return fmt.Sprintf("%sIndex ( %s ) didn't match.\n\n%sexpect: %v\n\n%sgot: %v", indent, r.Path(), chIndent, r.Expect, chIndent, r.Actual)
-			}
-		}
+// Indent is the indentation of the first line of r, deeper for records
+// nested in other records.
+func (r Record) Indent() string {
+	return strings.Repeat(" ", (r.depth+1)*padding)
+}
 
-		return fmt.Sprintf("%sField ( %s ) didn't match.\n\n%sexpect: %s\n\n%sgot: %s", indent, r.Path(), chIndent, r.Expect, chIndent, r.Actual)
-	default:
-		return fmt.Sprintf("%sField ( %s ) didn't match.\n\n%sgot %s error", indent, r.Path(), chIndent, r.Code)
-	}
+// DetailIndent is the indentation of the lines below the first line of r,
+// such as expect: and got:.
+func (r Record) DetailIndent() string {
+	return strings.Repeat(r.Indent(), 2)
 }
 
+// RecordCode tells why a record was made. Matchers outside of this package
+// can use their own codes after registering them with RegisterRecordCode.
 type RecordCode string
 
 const (
@@ -146,6 +117,42 @@ const (
 	RecordCodeNotFound       RecordCode = "not_found"
 	RecordCodeNotEqual       RecordCode = "not_equal"
 	RecordCodeUnmatchLength  RecordCode = "unmatch_length"
+	// RecordCodeConflict is an expectation whose matching elements were all
+	// assigned to other expectations.
+	RecordCodeConflict RecordCode = "conflict"
+	// RecordCodeUnexpectedElement is an element no expectation was assigned to.
+	RecordCodeUnexpectedElement RecordCode = "unexpected_element"
+	// RecordCodeDuplicate is an element equal to an earlier one.
+	RecordCodeDuplicate RecordCode = "duplicate"
+	// RecordCodeRemoved is an expectation with no counterpart in the target,
+	// located at the target index it is missing before.
+	RecordCodeRemoved RecordCode = "removed"
+	// RecordCodeInserted is an element of the target with no counterpart in
+	// the expectations.
+	RecordCodeInserted RecordCode = "inserted"
+	// RecordCodeUnexpectedField is a field of the target the expectation does
+	// not mention.
+	RecordCodeUnexpectedField RecordCode = "unexpected_field"
+	// RecordCodeNotAllowed is a value outside of the allowed set in Expect.
+	RecordCodeNotAllowed RecordCode = "not_allowed"
+	// RecordCodeParseError is a value that is not in the format in Expect.
+	RecordCodeParseError RecordCode = "parse_error"
+	// RecordCodeInvalidSignature is a signed value that fails verification.
+	RecordCodeInvalidSignature RecordCode = "invalid_signature"
+	// RecordCodeUnexpectedMatch is a value matching the expectation in
+	// Expect that Not negates.
+	RecordCodeUnexpectedMatch RecordCode = "unexpected_match"
+	// RecordCodeNotPointer is a non-pointer value given to Pointer or Ref.
+	RecordCodeNotPointer RecordCode = "not_pointer"
+	// RecordCodeZeroValue is a zero value given to a matcher that rejects
+	// them unless AllowZero is set.
+	RecordCodeZeroValue RecordCode = "zero_value"
+	// RecordCodePatternMismatch is a string that does not match the pattern
+	// in Expect.
+	RecordCodePatternMismatch RecordCode = "pattern_mismatch"
+	// RecordCodeNested groups the records below a value that matched
+	// itself, such as an XML element whose children didn't match.
+	RecordCodeNested RecordCode = "nested"
 )
 
 type Recorder interface {
@@ -153,23 +160,121 @@ type Recorder interface {
 	Records() []Record
 }
 
-var _ Recorder = &RefMatcher{}
-var _ Recorder = &structOfMatcher{}
+// result keeps the records of the last Match. Matchers built from other
+// matchers embed it to implement Records.
+type result struct {
+	records []Record
+}
+
+func (r result) Records() []Record {
+	return r.records
+}
+
+// Explainer is a matcher that keeps no state between matches. Explain
+// returns the records of why v doesn't match, none when it does, so that
+// one matcher can be shared by goroutines.
+type Explainer interface {
+	Title() string
+	Explain(v any) []Record
+}
 
-func recordNotEqual(m Matcher, key string, expect, actual any) Record {
+// Explain returns the records of why target doesn't match expect. Recorders
+// return the records of their last Match, which should be of target.
+func Explain(expect, target any) []Record {
+	switch m := expect.(type) {
+	case Explainer:
+		return m.Explain(target)
+	case Recorder:
+		return m.Records()
+	default:
+		return nil
+	}
+}
+
+// explainKind returns why a kind matcher m rejects v, given whether v is of
+// its kind: a nil target, a value of another type or a zero value without
+// AllowZero. It is generic so that m is only boxed when v is rejected.
+func explainKind[M Matcher](m M, kind string, v any, ok bool, o MatcherOptions) []Record {
+	if ok && (o.AllowZero || !isZero(v)) {
+		return nil
+	}
+
+	switch {
+	case v == nil:
+		return []Record{recordTargetIsNil(m, v)}
+	case !ok:
+		return []Record{recordUnexpectedType(m, kind, v)}
+	default:
+		return []Record{recordZeroValue(m, v)}
+	}
+}
+
+var _ Explainer = &RefMatcher{}
+var _ Explainer = &notMatcher{}
+var _ Explainer = beZero{}
+var _ Explainer = beAny{}
+var _ Explainer = beNil{}
+var _ Explainer = &anyOfMatcher{}
+var _ Explainer = anyBool{}
+var _ Explainer = anyInt{}
+var _ Explainer = anyString{}
+var _ Explainer = regExpMatcher{}
+var _ Explainer = emailMatcher{}
+var _ Explainer = anySlice{}
+var _ Explainer = sliceLenMatcher{}
+var _ Explainer = anyStruct{}
+var _ Explainer = anyTime{}
+var _ Explainer = timeCondMatcher{}
+var _ Explainer = anyUUID{}
+var _ Explainer = idMatcher{}
+var _ Explainer = jsonLiteral{}
+var _ Explainer = formatMatcher{}
+var _ Explainer = timeStringMatcher{}
+var _ Explainer = durationMatcher{}
+var _ Explainer = nullMatcher{}
+
+var _ Recorder = &structOfMatcher{}
+var _ Recorder = &sliceOfMatcher{}
+var _ Recorder = &jsonObjectMatcher{}
+var _ Recorder = &jsonArrayMatcher{}
+var _ Recorder = &eachMatcher{}
+var _ Recorder = &containElementMatcher{}
+var _ Recorder = &containInOrderMatcher{}
+var _ Recorder = &uniqueMatcher{}
+var _ Recorder = &atMatcher{}
+var _ Recorder = &typeMatcher{}
+var _ Recorder = &enumMatcher[string]{}
+var _ Recorder = &jwtMatcher{}
+var _ Recorder = &xmlMatcher{}
+var _ Recorder = &csvMatcher{}
+
+func recordNotEqual(m Matcher, seg PathSegment, expect, actual any) Record {
 	r := Record{
 		Matcher: m,
 		Root:    m,
-		Key:     key,
+		Key:     seg.Name,
+		Segment: seg,
 		Expect:  expect,
 		Actual:  actual,
 		Code:    RecordCodeNotEqual,
 	}
 
-	rr, ok := expect.(Recorder)
-	if ok {
-		r.SetChildren(rr.Records())
+	if rs := Explain(expect, actual); rs != nil {
+		r.SetChildren(rs)
+	}
+
+	return r
+}
+
+func recordNested(m Matcher, seg PathSegment, children []Record) Record {
+	r := Record{
+		Matcher: m,
+		Root:    m,
+		Key:     seg.Name,
+		Segment: seg,
+		Code:    RecordCodeNested,
 	}
+	r.SetChildren(children)
 
 	return r
 }
@@ -183,14 +288,87 @@ func recordUnmatchLength(m Matcher, expect, actual int) Record {
 	}
 }
 
-func recordNotFound(m Matcher, key string) Record {
+func recordNotFound(m Matcher, seg PathSegment) Record {
 	return Record{
 		Matcher: m,
-		Key:     key,
+		Key:     seg.Name,
+		Segment: seg,
 		Code:    RecordCodeNotFound,
 	}
 }
 
+func recordNotFoundExpect(m Matcher, seg PathSegment, expect any) Record {
+	return Record{
+		Matcher: m,
+		Key:     seg.Name,
+		Segment: seg,
+		Expect:  expect,
+		Code:    RecordCodeNotFound,
+	}
+}
+
+func recordConflict(m Matcher, seg PathSegment, expect any, candidates []int) Record {
+	return Record{
+		Matcher: m,
+		Key:     seg.Name,
+		Segment: seg,
+		Expect:  expect,
+		Actual:  candidates,
+		Code:    RecordCodeConflict,
+	}
+}
+
+func recordUnexpectedElement(m Matcher, seg PathSegment, actual any) Record {
+	return Record{
+		Matcher: m,
+		Key:     seg.Name,
+		Segment: seg,
+		Actual:  actual,
+		Code:    RecordCodeUnexpectedElement,
+	}
+}
+
+func recordDuplicate(m Matcher, seg PathSegment, first int, actual any) Record {
+	return Record{
+		Matcher: m,
+		Key:     seg.Name,
+		Segment: seg,
+		Expect:  first,
+		Actual:  actual,
+		Code:    RecordCodeDuplicate,
+	}
+}
+
+func recordRemoved(m Matcher, seg PathSegment, expect any) Record {
+	return Record{
+		Matcher: m,
+		Key:     seg.Name,
+		Segment: seg,
+		Expect:  expect,
+		Code:    RecordCodeRemoved,
+	}
+}
+
+func recordInserted(m Matcher, seg PathSegment, actual any) Record {
+	return Record{
+		Matcher: m,
+		Key:     seg.Name,
+		Segment: seg,
+		Actual:  actual,
+		Code:    RecordCodeInserted,
+	}
+}
+
+func recordUnexpectedField(m Matcher, seg PathSegment, actual any) Record {
+	return Record{
+		Matcher: m,
+		Key:     seg.Name,
+		Segment: seg,
+		Actual:  actual,
+		Code:    RecordCodeUnexpectedField,
+	}
+}
+
 func recordTargetIsNil(m Matcher, actual any) Record {
 	return Record{
 		Matcher: m,
@@ -207,3 +385,82 @@ func recordUnexpectedType(m Matcher, expect, actual any) Record {
 		Code:    RecordCodeUnexpectedType,
 	}
 }
+
+func recordNotAllowed(m Matcher, allowed, actual any) Record {
+	return Record{
+		Matcher: m,
+		Expect:  allowed,
+		Actual:  actual,
+		Code:    RecordCodeNotAllowed,
+	}
+}
+
+func recordParseError(m Matcher, format string, actual any, err error) Record {
+	return Record{
+		Matcher: m,
+		Expect:  format,
+		Actual:  actual,
+		Err:     err,
+		Code:    RecordCodeParseError,
+	}
+}
+
+func recordInvalidSignature(m Matcher, actual any, err error) Record {
+	return Record{
+		Matcher: m,
+		Actual:  actual,
+		Err:     err,
+		Code:    RecordCodeInvalidSignature,
+	}
+}
+
+func recordUnexpectedMatch(m Matcher, expect, actual any) Record {
+	r := Record{
+		Matcher: m,
+		Expect:  expect,
+		Actual:  actual,
+		Code:    RecordCodeUnexpectedMatch,
+	}
+
+	if rs := Explain(expect, actual); rs != nil {
+		r.SetChildren(rs)
+	}
+
+	return r
+}
+
+func recordNotPointer(m Matcher, actual any) Record {
+	return Record{
+		Matcher: m,
+		Actual:  actual,
+		Code:    RecordCodeNotPointer,
+	}
+}
+
+func recordZeroValue(m Matcher, actual any) Record {
+	return Record{
+		Matcher: m,
+		Actual:  actual,
+		Code:    RecordCodeZeroValue,
+	}
+}
+
+func recordPatternMismatch(m Matcher, pattern string, actual any) Record {
+	return Record{
+		Matcher: m,
+		Expect:  pattern,
+		Actual:  actual,
+		Code:    RecordCodePatternMismatch,
+	}
+}
+
+// recordMismatch is a value m does not match for no more specific reason
+// than the condition m describes.
+func recordMismatch(m Matcher, actual any) Record {
+	return Record{
+		Matcher: m,
+		Expect:  m,
+		Actual:  actual,
+		Code:    RecordCodeNotEqual,
+	}
+}
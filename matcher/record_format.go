@@ -0,0 +1,265 @@
+package matcher
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// RecordFormatter renders a record for failure messages, with paths
+// rendered by f. Nested records are rendered with their own Format.
+type RecordFormatter func(r Record, f PathFormatter) string
+
+var recordFormatters = struct {
+	sync.RWMutex
+	m map[RecordCode]RecordFormatter
+}{
+	m: map[RecordCode]RecordFormatter{},
+}
+
+func init() {
+	for code, fn := range map[RecordCode]RecordFormatter{
+		RecordCodeTargetIsNil:       formatTargetIsNil,
+		RecordCodeUnexpectedType:    formatUnexpectedType,
+		RecordCodeNotFound:          formatNotFound,
+		RecordCodeNotEqual:          formatNotEqual,
+		RecordCodeUnmatchLength:     formatUnmatchLength,
+		RecordCodeConflict:          formatConflict,
+		RecordCodeUnexpectedElement: formatUnexpectedElement,
+		RecordCodeDuplicate:         formatDuplicate,
+		RecordCodeRemoved:           formatRemoved,
+		RecordCodeInserted:          formatInserted,
+		RecordCodeUnexpectedField:   formatUnexpectedField,
+		RecordCodeNotAllowed:        formatNotAllowed,
+		RecordCodeParseError:        formatParseError,
+		RecordCodeInvalidSignature:  formatInvalidSignature,
+		RecordCodeUnexpectedMatch:   formatUnexpectedMatch,
+		RecordCodeNotPointer:        formatNotPointer,
+		RecordCodeZeroValue:         formatZeroValue,
+		RecordCodePatternMismatch:   formatPatternMismatch,
+		RecordCodeNested:            formatNested,
+	} {
+		RegisterRecordCode(code, fn)
+	}
+}
+
+// RegisterRecordCode makes records of code render with fn, so that custom
+// matchers can report their own codes, and returns the formatter it
+// replaces, if any. Registering a built-in code replaces its message. A nil
+// fn unregisters code, so that restoring the result of a first
+// registration makes its records render as an unknown code again.
+func RegisterRecordCode(code RecordCode, fn RecordFormatter) RecordFormatter {
+	recordFormatters.Lock()
+	defer recordFormatters.Unlock()
+
+	prev := recordFormatters.m[code]
+	if fn == nil {
+		delete(recordFormatters.m, code)
+	} else {
+		recordFormatters.m[code] = fn
+	}
+
+	return prev
+}
+
+// RecordCodes returns the registered codes in no particular order.
+func RecordCodes() []RecordCode {
+	recordFormatters.RLock()
+	defer recordFormatters.RUnlock()
+
+	codes := make([]RecordCode, 0, len(recordFormatters.m))
+	for code := range recordFormatters.m {
+		codes = append(codes, code)
+	}
+
+	return codes
+}
+
+func lookupRecordFormatter(code RecordCode) RecordFormatter {
+	recordFormatters.RLock()
+	defer recordFormatters.RUnlock()
+
+	fn, ok := recordFormatters.m[code]
+	if !ok {
+		return formatUnknown
+	}
+
+	return fn
+}
+
+// keyName is how messages call the element r is about.
+func keyName(r Record) string {
+	if isSliceOfMatcher(r.Matcher) {
+		return "Slice"
+	}
+
+	return "Field"
+}
+
+func formatChildren(r Record, f PathFormatter, sep string) string {
+	msg := ""
+	for _, c := range r.Children {
+		msg += c.Format(f) + sep
+	}
+
+	return msg
+}
+
+func formatTargetIsNil(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sTarget is nil. expect %s but got nil", r.Indent(), Describe(r.Matcher))
+}
+
+func formatUnexpectedType(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sTarget is unexpected type. expect %s but got %s", r.Indent(), r.Expect, typeName(reflect.TypeOf(r.Actual)))
+}
+
+func formatNotFound(r Record, f PathFormatter) string {
+	indent := r.Indent()
+	if isSliceOfMatcher(r.Matcher) {
+		if r.Expect != nil {
+			return fmt.Sprintf("%sIndex: %s is not found. no element matched\n\n%sexpect: %s", indent, r.PathWith(f), r.DetailIndent(), Describe(r.Expect))
+		}
+		return fmt.Sprintf("%sIndex: %s is not found.", indent, r.PathWith(f))
+	}
+
+	if len(r.Suggestions) > 0 {
+		return fmt.Sprintf("%s%s is not found. field: %s, did you mean %s?", indent, keyName(r), r.PathWith(f), strings.Join(r.Suggestions, " or "))
+	}
+
+	return fmt.Sprintf("%s%s is not found. field: %s", indent, keyName(r), r.PathWith(f))
+}
+
+// formatNotEqual lists the records of the expectation, such as those of
+// nested fields or elements, below the values that didn't match.
+func formatNotEqual(r Record, f PathFormatter) string {
+	indent, chIndent := r.Indent(), r.DetailIndent()
+
+	got := fmt.Sprintf("%v", r.Actual)
+	if isPlainStruct(r.Actual) {
+		got = fmt.Sprintf("%#v", r.Actual)
+	}
+
+	msg := fmt.Sprintf("%s%s ( %s ) didn't match.\n\n%sexpect: %s\n\n%sgot: %s", indent, elementName(r), r.PathWith(f), chIndent, Describe(r.Expect), chIndent, got)
+	if len(r.Children) > 0 {
+		msg += "\n\n" + formatNested(r, f)
+	}
+
+	return msg
+}
+
+// elementName calls the element of a record by its value: an index for
+// slices and for scalars at an index, and a field for structs, maps and
+// other values.
+func elementName(r Record) string {
+	t := reflect.TypeOf(r.Actual)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t != nil && t.Kind() == reflect.Slice:
+		return "Index"
+	case t != nil && (t.Kind() == reflect.Struct || t.Kind() == reflect.Map):
+		return "Field"
+	case r.Segment.Kind == SegmentIndex:
+		return "Index"
+	default:
+		return "Field"
+	}
+}
+
+// isPlainStruct reports whether v is a struct, or a pointer to one, that
+// has no String method, which %#v renders with its field names.
+func isPlainStruct(v any) bool {
+	if _, ok := v.(fmt.Stringer); ok {
+		return false
+	}
+
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t != nil && t.Kind() == reflect.Struct
+}
+
+func formatUnmatchLength(r Record, f PathFormatter) string {
+	msg := fmt.Sprintf("%s%s length is unmatched. expect %d but got %d", r.Indent(), keyName(r), r.Expect, r.Actual)
+	if len(r.Children) > 0 {
+		msg += "\n\n" + formatChildren(r, f, "\n\n")
+	}
+
+	return msg
+}
+
+func formatConflict(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sIndex: %s is not found. elements %v matched but were taken by other expectations\n\n%sexpect: %s", r.Indent(), r.PathWith(f), r.Actual, r.DetailIndent(), Describe(r.Expect))
+}
+
+func formatUnexpectedElement(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sIndex: %s is not expected.\n\n%sgot: %v", r.Indent(), r.PathWith(f), r.DetailIndent(), r.Actual)
+}
+
+func formatDuplicate(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sIndex: %s is a duplicate of index %v.\n\n%sgot: %v", r.Indent(), r.PathWith(f), r.Expect, r.DetailIndent(), r.Actual)
+}
+
+func formatRemoved(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sIndex: %s was removed.\n\n%sexpect: %s", r.Indent(), r.PathWith(f), r.DetailIndent(), Describe(r.Expect))
+}
+
+func formatInserted(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sIndex: %s was inserted.\n\n%sgot: %v", r.Indent(), r.PathWith(f), r.DetailIndent(), r.Actual)
+}
+
+func formatUnexpectedField(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sField: %s is not in the expectation.\n\n%sgot: %v", r.Indent(), r.PathWith(f), r.DetailIndent(), r.Actual)
+}
+
+func formatNotAllowed(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sValue is not allowed. expect one of %v but got %v", r.Indent(), r.Expect, r.Actual)
+}
+
+func formatParseError(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sValue is not a valid %s. %v\n\n%sgot: %v", r.Indent(), r.Expect, r.Err, r.DetailIndent(), r.Actual)
+}
+
+func formatInvalidSignature(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sSignature is invalid. %v\n\n%sgot: %v", r.Indent(), r.Err, r.DetailIndent(), r.Actual)
+}
+
+func formatUnexpectedMatch(r Record, f PathFormatter) string {
+	msg := fmt.Sprintf("%sValue matched unexpectedly. expected not to match %s, but it did\n\n%sgot: %v", r.Indent(), Describe(r.Expect), r.DetailIndent(), r.Actual)
+	for _, c := range r.Children {
+		msg += "\n\n" + c.Format(f)
+	}
+
+	return msg
+}
+
+func formatNotPointer(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sTarget is not a pointer. expected a pointer but got %s", r.Indent(), typeName(reflect.TypeOf(r.Actual)))
+}
+
+func formatZeroValue(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sValue is zero. expect %s but got %v", r.Indent(), Describe(r.Matcher), r.Actual)
+}
+
+func formatPatternMismatch(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sValue does not match the pattern %s.\n\n%sgot: %v", r.Indent(), r.Expect, r.DetailIndent(), r.Actual)
+}
+
+// formatNested renders only the records below r, whose paths go through r.
+func formatNested(r Record, f PathFormatter) string {
+	list := make([]string, len(r.Children))
+	for i, c := range r.Children {
+		list[i] = c.Format(f)
+	}
+
+	return strings.Join(list, "\n\n")
+}
+
+func formatUnknown(r Record, f PathFormatter) string {
+	return fmt.Sprintf("%sField ( %s ) didn't match.\n\n%sgot %s error", r.Indent(), r.PathWith(f), r.DetailIndent(), r.Code)
+}
@@ -1,9 +1,8 @@
 package matcher
 
 import (
+	"fmt"
 	"reflect"
-	"strconv"
-	"testing"
 
 	"github.com/version-1/go-matcha/matcher/slices"
 )
@@ -12,17 +11,20 @@ type anySlice struct {
 	options MatcherOptions
 }
 
+func (m anySlice) Title() string {
+	return "SliceMatcher got errors."
+}
+
 func BeSlice() *anySlice {
 	return &anySlice{}
 }
 
 func (m anySlice) Match(v any) bool {
-	if !m.options.AllowZero && isZero(v) {
-		return false
-	}
+	return len(m.Explain(v)) == 0
+}
 
-	s := MaySlice(v)
-	return s.IsSlice()
+func (m anySlice) Explain(v any) []Record {
+	return explainKind(m, "slice", v, MaySlice(v).IsSlice(), m.options)
 }
 
 func (m anySlice) Not() Matcher {
@@ -38,6 +40,14 @@ func (m anySlice) AllowZero() Matcher {
 	return m
 }
 
+func (m anySlice) String() string {
+	return modifierString("slice", m.options)
+}
+
+func (m anySlice) Describe() string {
+	return describeKind("slice", m.options)
+}
+
 func SliceOf(elements []any, opts ...func(m *slices.MatcherOptions)) Matcher {
 	o := slices.MatcherOptions{
 		Order:    true,
@@ -53,18 +63,36 @@ func SliceOf(elements []any, opts ...func(m *slices.MatcherOptions)) Matcher {
 type sliceOfMatcher struct {
 	elements []any
 	options  slices.MatcherOptions
-	records  []Record
+	result
+	// coerce is whether the current match compares with coercion.
+	coerce bool
 }
 
 func (m *sliceOfMatcher) Title() string {
 	return "SliceOfMatcher got errors"
 }
 
-func (m sliceOfMatcher) Records() []Record {
-	return m.records
+func (m *sliceOfMatcher) Match(v any) bool {
+	return m.match(v, m.options.Coercion)
 }
 
-func (m *sliceOfMatcher) Match(v any) bool {
+func (m *sliceOfMatcher) matchCoerced(v any) bool {
+	return m.match(v, true)
+}
+
+func (m *sliceOfMatcher) equal(expect, target any) bool {
+	unwrapped := false
+	if m.options.UnwrapValuers {
+		target, unwrapped = unwrapValuer(expect, target)
+	}
+
+	return equal(expect, target, m.coerce || unwrapped)
+}
+
+func (m *sliceOfMatcher) match(v any, coerce bool) bool {
+	m.records = nil
+	m.coerce = coerce
+
 	if v == nil {
 		r := recordTargetIsNil(m, v)
 		m.records = append(m.records, r)
@@ -80,6 +108,13 @@ func (m *sliceOfMatcher) Match(v any) bool {
 
 	if !m.options.Contains && len(m.elements) != vw.Length() {
 		r := recordUnmatchLength(m, len(m.elements), vw.Length())
+		if m.options.Order {
+			r.SetChildren(m.diffRecords(vw))
+		} else {
+			m.matchUnordered(vw)
+			r.SetChildren(m.records)
+			m.records = nil
+		}
 		m.records = append(m.records, r)
 		return false
 	}
@@ -88,13 +123,13 @@ func (m *sliceOfMatcher) Match(v any) bool {
 		for i := range m.elements {
 			ele, ok := vw.Index(i)
 			if !ok {
-				r := recordNotFound(m, strconv.Itoa(i))
+				r := recordNotFound(m, IndexSegment(i))
 				m.records = append(m.records, r)
 				continue
 			}
 
-			if !Equal(m.elements[i], ele) {
-				r := recordNotEqual(m, strconv.Itoa(i), m.elements[i], ele)
+			if !m.equal(m.elements[i], ele) {
+				r := recordNotEqual(m, IndexSegment(i), m.elements[i], ele)
 				m.records = append(m.records, r)
 			}
 		}
@@ -102,18 +137,109 @@ func (m *sliceOfMatcher) Match(v any) bool {
 		return len(m.records) == 0
 	}
 
-	maps := map[int]bool{}
-	for i := 0; i < len(m.elements); i++ {
-		idx := vw.FindIndex(m.elements[i], maps)
-		if idx < 0 {
-			ele, _ := vw.Index(i)
-			r := recordNotEqual(m, strconv.Itoa(i), m.elements[i], ele)
-			m.records = append(m.records, r)
+	m.matchUnordered(vw)
+
+	return len(m.records) == 0
+}
+
+// diffRecords explains a length mismatch with the elements that were
+// inserted, removed or changed compared to the expectations. All records
+// are indexed by the target.
+func (m *sliceOfMatcher) diffRecords(vw maySlice) []Record {
+	res := []Record{}
+	for _, e := range diffSlice(m.elements, vw, m.equal) {
+		switch e.op {
+		case diffChange:
+			ele := vw.element(e.actual)
+			// run the expectation again so that nested records belong to this element.
+			m.equal(m.elements[e.expect], ele)
+			res = append(res, recordNotEqual(m, IndexSegment(e.actual), m.elements[e.expect], ele))
+		case diffRemove:
+			res = append(res, recordRemoved(m, IndexSegment(e.actual), m.elements[e.expect]))
+		case diffInsert:
+			res = append(res, recordInserted(m, IndexSegment(e.actual), vw.element(e.actual)))
 		}
-		maps[idx] = true
 	}
 
-	return len(m.records) == 0
+	return res
+}
+
+// matchUnordered assigns every expectation to a distinct element with a
+// maximum bipartite matching, so that a loose matcher does not take an
+// element that a stricter expectation needs.
+func (m *sliceOfMatcher) matchUnordered(vw maySlice) {
+	candidates := make([][]int, len(m.elements))
+	for i, e := range m.elements {
+		for j := 0; j < vw.Length(); j++ {
+			if m.equal(e, vw.element(j)) {
+				candidates[i] = append(candidates[i], j)
+			}
+		}
+	}
+
+	owner := maxBipartiteMatching(candidates, vw.Length())
+	assigned := make([]bool, len(m.elements))
+	for _, i := range owner {
+		if i >= 0 {
+			assigned[i] = true
+		}
+	}
+
+	for i, e := range m.elements {
+		if assigned[i] {
+			continue
+		}
+
+		if len(candidates[i]) == 0 {
+			m.records = append(m.records, recordNotFoundExpect(m, IndexSegment(i), e))
+			continue
+		}
+
+		m.records = append(m.records, recordConflict(m, IndexSegment(i), e, candidates[i]))
+	}
+
+	if m.options.Contains {
+		return
+	}
+
+	for j, i := range owner {
+		if i < 0 {
+			m.records = append(m.records, recordUnexpectedElement(m, IndexSegment(j), vw.element(j)))
+		}
+	}
+}
+
+// maxBipartiteMatching returns, for each of the n right-hand nodes, the
+// index of the left-hand node assigned to it or -1. candidates lists the
+// right-hand nodes each left-hand node may be assigned to.
+func maxBipartiteMatching(candidates [][]int, n int) []int {
+	owner := make([]int, n)
+	for j := range owner {
+		owner[j] = -1
+	}
+
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for _, j := range candidates[i] {
+			if seen[j] {
+				continue
+			}
+			seen[j] = true
+
+			if owner[j] < 0 || augment(owner[j], seen) {
+				owner[j] = i
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for i := range candidates {
+		augment(i, make([]bool, n))
+	}
+
+	return owner
 }
 
 func (m sliceOfMatcher) Not() Matcher {
@@ -124,21 +250,47 @@ func (m sliceOfMatcher) Pointer() Matcher {
 	return Ref(&m)
 }
 
+func (m sliceOfMatcher) Describe() string {
+	verb := "of"
+	if m.options.Contains {
+		verb = "containing"
+	}
+
+	s := fmt.Sprintf("a slice %s %s", verb, describeList(m.elements))
+	if !m.options.Order {
+		s += " in any order"
+	}
+
+	return s
+}
+
 type sliceLenMatcher struct {
 	n int
 }
 
+func (m sliceLenMatcher) Title() string {
+	return "SliceLenMatcher got errors."
+}
+
 func SliceLen(n int) Matcher {
-	return sliceLenMatcher{n: n}
+	return &sliceLenMatcher{n: n}
 }
 
 func (m sliceLenMatcher) Match(v any) bool {
-	vw := MaySlice(v)
-	if !vw.IsSlice() {
-		return false
+	return len(m.Explain(v)) == 0
+}
+
+func (m sliceLenMatcher) Explain(v any) []Record {
+	vw, r, ok := checkSlice(m, v)
+	if !ok {
+		return []Record{r}
 	}
 
-	return vw.Length() == m.n
+	if vw.Length() != m.n {
+		return []Record{recordUnmatchLength(m, m.n, vw.Length())}
+	}
+
+	return nil
 }
 
 func (m sliceLenMatcher) Not() Matcher {
@@ -149,17 +301,21 @@ func (m sliceLenMatcher) Pointer() Matcher {
 	return Ref(m)
 }
 
-func MaySlice(raw any) *maySlice {
-	v := reflect.ValueOf(raw)
-	t := v.Type()
-	return &maySlice{raw: raw, v: &v, t: &t}
+func (m sliceLenMatcher) String() string {
+	return fmt.Sprintf("slice[%d]", m.n)
+}
+
+func (m sliceLenMatcher) Describe() string {
+	return fmt.Sprintf("a slice of length %d", m.n)
+}
+
+func MaySlice(raw any) maySlice {
+	return maySlice{raw: raw, v: reflect.ValueOf(raw)}
 }
 
 type maySlice struct {
-	raw  any
-	t    *reflect.Type
-	v    *reflect.Value
-	test *testing.T
+	raw any
+	v   reflect.Value
 }
 
 func (w maySlice) Length() int {
@@ -179,23 +335,20 @@ func (w maySlice) Index(n int) (any, bool) {
 		return nil, false
 	}
 
-	res := w.v.Index(n).Interface()
-
-	return res, true
+	return w.element(n), true
 }
 
 func (w maySlice) FindIndex(target any, excludes map[int]bool) int {
-	for i := 0; i < w.Length(); i++ {
-		v, ok := w.Index(i)
-		if !ok {
-			return -1
-		}
+	if !w.IsSlice() {
+		return -1
+	}
 
-		if _, ok := excludes[i]; ok {
+	for i := 0; i < w.v.Len(); i++ {
+		if excludes[i] {
 			continue
 		}
 
-		if Equal(v, target) {
+		if Equal(target, w.element(i)) {
 			return i
 		}
 	}
@@ -204,18 +357,25 @@ func (w maySlice) FindIndex(target any, excludes map[int]bool) int {
 }
 
 func (w maySlice) IsSlice() bool {
-	if w.t == nil {
-		return false
-	}
-	return isSlice(*w.t)
+	return isSliceKind(w.v.Kind())
 }
 
-func isSlice(v reflect.Type) bool {
-	if v == nil {
-		return false
+// element skips reflection for the slice types that are matched most often.
+func (w maySlice) element(i int) any {
+	switch s := w.raw.(type) {
+	case []any:
+		return s[i]
+	case []string:
+		return s[i]
+	case []int:
+		return s[i]
 	}
 
-	switch v.Kind() {
+	return w.v.Index(i).Interface()
+}
+
+func isSliceKind(k reflect.Kind) bool {
+	switch k {
 	case reflect.Slice, reflect.Array:
 		return true
 	default:
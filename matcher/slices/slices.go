@@ -1,9 +1,11 @@
 package slices
 
 type MatcherOptions struct {
-	AllowZero bool
-	Order     bool
-	Contains  bool
+	AllowZero     bool
+	Order         bool
+	Contains      bool
+	Coercion      bool
+	UnwrapValuers bool
 }
 
 func WithPersistOrder(v bool) func(*MatcherOptions) {
@@ -17,3 +19,21 @@ func WithContains(v bool) func(*MatcherOptions) {
 		o.Contains = v
 	}
 }
+
+// WithCoercion compares elements like matcher.EqualLoose.
+func WithCoercion() func(*MatcherOptions) {
+	return func(o *MatcherOptions) {
+		o.Coercion = true
+	}
+}
+
+// WithUnwrapValuers compares plain element expectations with the value of
+// driver.Valuer elements like matcher.EqualLoose, e.g. "Alice" with
+// sql.NullString{String: "Alice", Valid: true} and 30 with
+// sql.NullInt64{Int64: 30, Valid: true}. Matchers still get the element as
+// is.
+func WithUnwrapValuers() func(*MatcherOptions) {
+	return func(o *MatcherOptions) {
+		o.UnwrapValuers = true
+	}
+}
@@ -0,0 +1,110 @@
+package sqlmatch
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// fakeResult is what the fake driver returns for a query.
+type fakeResult struct {
+	columns []string
+	types   []reflect.Type
+	rows    [][]driver.Value
+	err     error
+}
+
+var fakeResults sync.Map // map[string]fakeResult
+
+func init() {
+	sql.Register("sqlmatch-fake", fakeDriver{})
+}
+
+// openFake returns a database that answers query with result.
+func openFake(query string, result fakeResult) (*sql.DB, error) {
+	fakeResults.Store(query, result)
+	return sql.Open("sqlmatch-fake", "")
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return fakeConn{}, nil
+}
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{query: query}, nil
+}
+
+func (fakeConn) Close() error {
+	return nil
+}
+
+func (fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fake: transactions are not supported")
+}
+
+type fakeStmt struct {
+	query string
+}
+
+func (fakeStmt) Close() error {
+	return nil
+}
+
+func (fakeStmt) NumInput() int {
+	return -1
+}
+
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("fake: exec is not supported")
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	v, ok := fakeResults.Load(s.query)
+	if !ok {
+		return nil, errors.New("fake: unknown query " + s.query)
+	}
+
+	return &fakeRows{result: v.(fakeResult)}, nil
+}
+
+type fakeRows struct {
+	result fakeResult
+	i      int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.result.columns
+}
+
+func (r *fakeRows) ColumnTypeScanType(i int) reflect.Type {
+	if r.result.types == nil {
+		return reflect.TypeOf(new(any)).Elem()
+	}
+
+	return r.result.types[i]
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.result.rows) {
+		if r.result.err != nil {
+			return r.result.err
+		}
+		return io.EOF
+	}
+
+	copy(dest, r.result.rows[r.i])
+	r.i++
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// Package sqlmatch matches database/sql query results.
+package sqlmatch
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/version-1/go-matcha/matcher"
+)
+
+// Rows matches a *sql.Rows by scanning every row into a matcher.Row
+// keyed by column name and matching the rows against expect, usually
+// SliceOf(StructOf(...)). Use slices.WithPersistOrder(false) on the SliceOf
+// for queries without ORDER BY. A []any expectation is matched in order,
+// with map[string]any elements matched like StructOf.
+//
+// Rows are consumed by the first Match and the result is reused when the
+// same *sql.Rows is matched again.
+func Rows(expect any) matcher.Matcher {
+	if elements, ok := expect.([]any); ok {
+		list := make([]any, len(elements))
+		for i, e := range elements {
+			if row, ok := e.(map[string]any); ok {
+				e = matcher.StructOf(row)
+			}
+			list[i] = e
+		}
+		expect = matcher.SliceOf(list)
+	}
+
+	return &rowsMatcher{expect: expect}
+}
+
+type rowsMatcher struct {
+	expect  any
+	rows    *sql.Rows
+	result  []matcher.Row
+	err     error
+	records []matcher.Record
+}
+
+func (m rowsMatcher) Title() string {
+	return "RowsMatcher got errors."
+}
+
+func (m rowsMatcher) Records() []matcher.Record {
+	return m.records
+}
+
+func (m *rowsMatcher) Match(v any) bool {
+	m.records = nil
+
+	rows, ok := v.(*sql.Rows)
+	if !ok || rows == nil {
+		m.records = append(m.records, matcher.Record{
+			Matcher: m,
+			Code:    matcher.RecordCodeUnexpectedType,
+			Expect:  "*database/sql.Rows",
+			Actual:  v,
+		})
+		return false
+	}
+
+	if rows != m.rows {
+		m.rows = rows
+		m.result, m.err = scan(rows)
+	}
+
+	if m.err != nil {
+		m.records = append(m.records, matcher.Record{
+			Matcher: m,
+			Code:    matcher.RecordCodeParseError,
+			Expect:  "rows",
+			Actual:  v,
+			Err:     m.err,
+		})
+		return false
+	}
+
+	if matcher.Equal(m.expect, m.result) {
+		return true
+	}
+
+	if rs := matcher.Explain(m.expect, m.result); len(rs) > 0 {
+		m.records = append(m.records, rs...)
+	} else {
+		m.records = append(m.records, matcher.Record{
+			Matcher: m,
+			Code:    matcher.RecordCodeNotEqual,
+			Expect:  m.expect,
+			Actual:  m.result,
+		})
+	}
+
+	return false
+}
+
+func (m rowsMatcher) Not() matcher.Matcher {
+	return matcher.Not(&m)
+}
+
+func (m rowsMatcher) Pointer() matcher.Matcher {
+	return matcher.Ref(&m)
+}
+
+func (m rowsMatcher) String() string {
+	return fmt.Sprintf("rows(%v)", m.expect)
+}
+
+func (m rowsMatcher) Describe() string {
+	return "rows of " + matcher.Describe(m.expect)
+}
+
+// scan reads all rows and closes them. Values are converted to the scan
+// type the driver reports for their column when possible, e.g. []byte to
+// string.
+func scan(rows *sql.Rows) ([]matcher.Row, error) {
+	defer rows.Close()
+
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	res := []matcher.Row{}
+	for rows.Next() {
+		values := make([]any, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		row := make(matcher.Row, len(columns))
+		for i, c := range columns {
+			row[c.Name()] = convert(values[i], c.ScanType())
+		}
+		res = append(res, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func convert(v any, t reflect.Type) any {
+	if v == nil || t == nil || t.Kind() == reflect.Interface {
+		return v
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Type() == t || !rv.Type().ConvertibleTo(t) {
+		return v
+	}
+
+	// numbers are not converted to strings, which would give a rune.
+	if t.Kind() == reflect.String && rv.Kind() != reflect.String && rv.Kind() != reflect.Slice {
+		return v
+	}
+
+	return rv.Convert(t).Interface()
+}
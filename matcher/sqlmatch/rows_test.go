@@ -0,0 +1,143 @@
+package sqlmatch
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/version-1/go-matcha/matcher"
+	"github.com/version-1/go-matcha/matcher/slices"
+	"github.com/version-1/go-matcha/matcher/structs"
+)
+
+func TestRows(t *testing.T) {
+	users := fakeResult{
+		columns: []string{"id", "name", "email"},
+		types:   []reflect.Type{reflect.TypeOf(int64(0)), reflect.TypeOf(""), reflect.TypeOf("")},
+		rows: [][]driver.Value{
+			{int64(1), []byte("alice"), "alice@example.com"},
+			{int64(2), []byte("bob"), nil},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		result fakeResult
+		expect any
+		ans    bool
+	}{
+		{"ordered", users, matcher.SliceOf([]any{
+			matcher.StructOf(matcher.StructMap{"id": int64(1), "name": "alice", "email": matcher.Email()}),
+			matcher.StructOf(matcher.StructMap{"id": int64(2), "name": "bob", "email": nil}),
+		}), true},
+		{"ordered with other order", users, matcher.SliceOf([]any{
+			matcher.StructOf(matcher.StructMap{"id": int64(2), "name": "bob", "email": nil}),
+			matcher.StructOf(matcher.StructMap{"id": int64(1), "name": "alice", "email": matcher.Email()}),
+		}), false},
+		{"unordered", users, matcher.SliceOf([]any{
+			matcher.StructOf(matcher.StructMap{"id": int64(2), "name": "bob", "email": nil}),
+			matcher.StructOf(matcher.StructMap{"id": int64(1), "name": "alice", "email": matcher.Email()}),
+		}, slices.WithPersistOrder(false)), true},
+		{"slice literal", users, []any{
+			map[string]any{"id": int64(1), "name": "alice", "email": "alice@example.com"},
+			map[string]any{"id": int64(2), "name": "bob", "email": nil},
+		}, true},
+		{"each row", users, matcher.Each(matcher.StructOf(matcher.StructMap{
+			"id": matcher.BeOfType[int64](),
+		}, structs.WithContains(true))), true},
+		{"no rows", fakeResult{columns: []string{"id"}}, matcher.SliceOf([]any{}), true},
+		{"untyped columns", fakeResult{
+			columns: []string{"name"},
+			rows:    [][]driver.Value{{[]byte("alice")}},
+		}, matcher.SliceOf([]any{
+			matcher.StructOf(matcher.StructMap{"name": []byte("alice")}),
+		}), true},
+		{"row error", fakeResult{
+			columns: []string{"id"},
+			err:     errors.New("connection reset"),
+		}, matcher.SliceOf([]any{}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := openFake(tt.name, tt.result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			rows, err := db.Query(tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := Rows(tt.expect)
+			if matcher.Equal(m, rows) != tt.ans {
+				t.Errorf("Equal(%v, rows) should return %v", m, tt.ans)
+			}
+
+			// rows are consumed, so matching again reuses the result.
+			if matcher.Equal(m, rows) != tt.ans {
+				t.Errorf("Equal(%v, rows) should return %v when matched again", m, tt.ans)
+			}
+		})
+	}
+}
+
+func TestRowsRecords(t *testing.T) {
+	db, err := openFake("records", fakeResult{
+		columns: []string{"id", "name"},
+		types:   []reflect.Type{reflect.TypeOf(int64(0)), reflect.TypeOf("")},
+		rows: [][]driver.Value{
+			{int64(1), "alice"},
+			{int64(2), "bob"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("records")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := Rows(matcher.SliceOf([]any{
+		matcher.StructOf(matcher.StructMap{"id": int64(1), "name": "alice"}),
+		matcher.StructOf(matcher.StructMap{"id": int64(2), "name": "carol"}),
+	}))
+	if matcher.Equal(m, rows) {
+		t.Fatal("Equal should return false")
+	}
+
+	records := m.(matcher.Recorder).Records()
+	if len(records) != 1 || len(records[0].Children) != 1 {
+		t.Fatalf("records should have one row with one column, got %v", records)
+	}
+
+	if p := records[0].Children[0].PathWith(matcher.GoPath); p != `[1]["name"]` {
+		t.Errorf("Path should be [1][\"name\"], got %s", p)
+	}
+}
+
+func TestRowsUnexpectedType(t *testing.T) {
+	m := Rows([]any{})
+	if matcher.Equal(m, []map[string]any{}) {
+		t.Fatal("Equal should return false")
+	}
+
+	records := m.(matcher.Recorder).Records()
+	if len(records) != 1 || records[0].Code != matcher.RecordCodeUnexpectedType {
+		t.Errorf("records should be one unexpected type record, got %v", records)
+	}
+}
+
+func TestRowsString(t *testing.T) {
+	s := Rows([]any{map[string]any{"id": 1}}).(fmt.Stringer).String()
+	if _, err := matcher.Parse(s); err == nil {
+		t.Errorf("Parse(%s) should fail", s)
+	}
+}
@@ -1,8 +1,20 @@
 package matcher
 
 import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
 	"net/mail"
+	"net/netip"
+	"net/url"
 	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/version-1/go-matcha/matcher/urls"
 )
 
 // string
@@ -14,14 +26,18 @@ type anyString struct {
 	options MatcherOptions
 }
 
+func (m anyString) Title() string {
+	return "StringMatcher got errors."
+}
+
 var _ Matcher = anyString{}
 
 func (m anyString) Match(v any) bool {
-	if !m.options.AllowZero && isZero(v) {
-		return false
-	}
+	return len(m.Explain(v)) == 0
+}
 
-	return typeMatch[string](v)
+func (m anyString) Explain(v any) []Record {
+	return explainKind(m, "string", v, kindMatch[string](v, m.options), m.options)
 }
 
 func (m anyString) Not() Matcher {
@@ -37,29 +53,60 @@ func (m anyString) AllowZero() Matcher {
 	return m
 }
 
+func (m anyString) AllowNamed() Matcher {
+	m.options.AllowNamed = true
+	return m
+}
+
+func (m anyString) String() string {
+	return modifierString("string", m.options)
+}
+
+func (m anyString) Describe() string {
+	return describeKind("string", m.options)
+}
+
 type regExpMatcher struct {
 	regexp *regexp.Regexp
 }
 
+func (m regExpMatcher) Title() string {
+	return "RegExpMatcher got errors."
+}
+
 func RegExp(r string) Matcher {
 	m := regexp.MustCompile(r)
 
-	return &regExpMatcher{m}
+	return &regExpMatcher{regexp: m}
+}
+
+func newRegExp(r string) (Matcher, error) {
+	m, err := regexp.Compile(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &regExpMatcher{regexp: m}, nil
 }
 
 func (m regExpMatcher) Match(v any) bool {
-	if v == nil {
-		return false
+	return len(m.Explain(v)) == 0
+}
+
+func (m regExpMatcher) Explain(v any) []Record {
+	s, ok := stringValue(v)
+	if !ok {
+		if v == nil || v == (*string)(nil) {
+			return []Record{recordTargetIsNil(m, v)}
+		}
+		return []Record{recordUnexpectedType(m, "string", v)}
 	}
 
-	switch vv := v.(type) {
-	case string:
-		return m.regexp.MatchString(vv)
-	case *string:
-		return m.regexp.MatchString(*vv)
-	default:
-		return false
+	if !m.regexp.MatchString(s) {
+		return []Record{recordPatternMismatch(m, m.regexp.String(), v)}
 	}
+
+	return nil
 }
 
 func (m regExpMatcher) Not() Matcher {
@@ -70,33 +117,43 @@ func (m regExpMatcher) Pointer() Matcher {
 	return Ref(m)
 }
 
+func (m regExpMatcher) String() string {
+	return regExpString(m.regexp.String())
+}
+
+func (m regExpMatcher) Describe() string {
+	return "a string matching " + m.regexp.String()
+}
+
 type emailMatcher struct{}
 
+func (m emailMatcher) Title() string {
+	return "EmailMatcher got errors."
+}
+
 func Email() Matcher {
 	return &emailMatcher{}
 }
 
 func (m emailMatcher) Match(v any) bool {
-	if v == nil {
-		return false
-	}
+	return len(m.Explain(v)) == 0
+}
 
-	var target string
-	switch vv := v.(type) {
-	case string:
-		target = vv
-	case *string:
-		target = *vv
-	default:
-		return false
+func (m emailMatcher) Explain(v any) []Record {
+	target, ok := stringValue(v)
+	if !ok {
+		if v == nil || v == (*string)(nil) {
+			return []Record{recordTargetIsNil(m, v)}
+		}
+		return []Record{recordUnexpectedType(m, "string", v)}
 	}
 
 	_, err := mail.ParseAddress(target)
 	if err != nil {
-		return false
+		return []Record{recordParseError(m, "email", v, err)}
 	}
 
-	return true
+	return nil
 }
 
 func (m emailMatcher) Not() Matcher {
@@ -106,3 +163,295 @@ func (m emailMatcher) Not() Matcher {
 func (m emailMatcher) Pointer() Matcher {
 	return Ref(m)
 }
+
+func (m emailMatcher) String() string {
+	return "email"
+}
+
+func (m emailMatcher) Describe() string {
+	return "an email"
+}
+
+// formatMatcher matches strings that parse as a format, recording the
+// parse error when they don't. The empty string is rejected unless
+// AllowZero is set, even by formats that would decode it, such as hex.
+type formatMatcher struct {
+	name    string
+	desc    string
+	args    []string
+	parse   func(s string) error
+	options MatcherOptions
+}
+
+func (m formatMatcher) Title() string {
+	return "FormatMatcher got errors."
+}
+
+func (m formatMatcher) Match(v any) bool {
+	return len(m.Explain(v)) == 0
+}
+
+func (m formatMatcher) Explain(v any) []Record {
+	s, ok := stringValue(v)
+	if !ok {
+		return []Record{recordUnexpectedType(m, "string", v)}
+	}
+
+	if s == "" {
+		if m.options.AllowZero {
+			return nil
+		}
+		return []Record{recordZeroValue(m, v)}
+	}
+
+	if err := m.parse(s); err != nil {
+		return []Record{recordParseError(m, m.name, v, err)}
+	}
+
+	return nil
+}
+
+func (m formatMatcher) Not() Matcher {
+	return Not(m)
+}
+
+func (m formatMatcher) Pointer() Matcher {
+	return Ref(m)
+}
+
+func (m formatMatcher) AllowZero() Matcher {
+	m.options.AllowZero = true
+	return m
+}
+
+func (m formatMatcher) String() string {
+	name := m.name
+	if len(m.args) > 0 {
+		name = fmt.Sprintf("%s(%s)", name, strings.Join(m.args, ", "))
+	}
+
+	return modifierString(name, m.options)
+}
+
+func (m formatMatcher) Describe() string {
+	if m.options.AllowZero {
+		return m.desc + " or an empty string"
+	}
+
+	return m.desc
+}
+
+// BeURL matches absolute URLs, optionally restricted to some schemes and
+// hosts with urls.WithSchemes and urls.WithHosts.
+func BeURL(opts ...func(*urls.MatcherOptions)) *formatMatcher {
+	o := urls.MatcherOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	args := []string{}
+	if len(o.Schemes) > 0 {
+		args = append(args, "scheme "+strings.Join(o.Schemes, "|"))
+	}
+	if len(o.Hosts) > 0 {
+		args = append(args, "host "+strings.Join(o.Hosts, "|"))
+	}
+
+	return &formatMatcher{name: "url", desc: "a URL", args: args, parse: func(s string) error {
+		u, err := url.Parse(s)
+		if err != nil {
+			return err
+		}
+
+		if u.Scheme == "" {
+			return fmt.Errorf("missing scheme in %q", s)
+		}
+
+		if u.Host == "" && u.Opaque == "" {
+			return fmt.Errorf("missing host in %q", s)
+		}
+
+		if len(o.Schemes) > 0 && !slices.ContainsFunc(o.Schemes, func(scheme string) bool {
+			return strings.EqualFold(scheme, u.Scheme)
+		}) {
+			return fmt.Errorf("scheme %q is not one of %v", u.Scheme, o.Schemes)
+		}
+
+		if len(o.Hosts) > 0 && !slices.ContainsFunc(o.Hosts, func(host string) bool {
+			return matchHost(host, u.Hostname())
+		}) {
+			return fmt.Errorf("host %q is not one of %v", u.Hostname(), o.Hosts)
+		}
+
+		return nil
+	}}
+}
+
+func matchHost(pattern, host string) bool {
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return len(host) > len(suffix)+1 && strings.HasSuffix(strings.ToLower(host), "."+strings.ToLower(suffix))
+	}
+
+	return strings.EqualFold(pattern, host)
+}
+
+// BeIP matches IPv4 and IPv6 addresses.
+func BeIP() *formatMatcher {
+	return &formatMatcher{name: "ip", desc: "an IP address", parse: func(s string) error {
+		_, err := netip.ParseAddr(s)
+		return err
+	}}
+}
+
+// BeIPv4 matches IPv4 addresses in dotted decimal form.
+func BeIPv4() *formatMatcher {
+	return &formatMatcher{name: "ipv4", desc: "an IPv4 address", parse: func(s string) error {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return err
+		}
+
+		if !addr.Is4() {
+			return fmt.Errorf("%q is not an IPv4 address", s)
+		}
+
+		return nil
+	}}
+}
+
+// BeIPv6 matches IPv6 addresses, including IPv4-mapped ones.
+func BeIPv6() *formatMatcher {
+	return &formatMatcher{name: "ipv6", desc: "an IPv6 address", parse: func(s string) error {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return err
+		}
+
+		if !addr.Is6() {
+			return fmt.Errorf("%q is not an IPv6 address", s)
+		}
+
+		return nil
+	}}
+}
+
+// BeCIDR matches IP prefixes such as 10.0.0.0/8.
+func BeCIDR() *formatMatcher {
+	return &formatMatcher{name: "cidr", desc: "a CIDR prefix", parse: func(s string) error {
+		_, err := netip.ParsePrefix(s)
+		return err
+	}}
+}
+
+// BeHostname matches RFC 1123 host names.
+func BeHostname() *formatMatcher {
+	return &formatMatcher{name: "hostname", desc: "a hostname", parse: parseHostname}
+}
+
+func parseHostname(s string) error {
+	name := strings.TrimSuffix(s, ".")
+	if name == "" {
+		return errors.New("empty hostname")
+	}
+
+	if len(name) > 253 {
+		return fmt.Errorf("hostname is %d characters long, longer than 253", len(name))
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 {
+			return fmt.Errorf("label %q must be 1 to 63 characters long", label)
+		}
+
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("label %q must not start or end with a hyphen", label)
+		}
+
+		for _, c := range label {
+			if c != '-' && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') && !('0' <= c && c <= '9') {
+				return fmt.Errorf("label %q has invalid character %q", label, c)
+			}
+		}
+	}
+
+	return nil
+}
+
+// semverPattern is the pattern suggested by https://semver.org.
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// BeSemver matches semantic versions such as 1.2.3-rc.1+build.5, without a
+// leading v.
+func BeSemver() *formatMatcher {
+	return &formatMatcher{name: "semver", desc: "a semantic version", parse: func(s string) error {
+		if !semverPattern.MatchString(s) {
+			return fmt.Errorf("%q is not a semantic version", s)
+		}
+
+		return nil
+	}}
+}
+
+// BeBase64 matches standard or URL-safe base64, padded or not.
+func BeBase64() *formatMatcher {
+	return &formatMatcher{name: "base64", desc: "a base64 string", parse: func(s string) error {
+		_, err := base64.StdEncoding.DecodeString(s)
+		if err == nil {
+			return nil
+		}
+
+		for _, enc := range []*base64.Encoding{base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+			if _, e := enc.DecodeString(s); e == nil {
+				return nil
+			}
+		}
+
+		return err
+	}}
+}
+
+// BeHex matches hexadecimal encoded bytes.
+func BeHex() *formatMatcher {
+	return &formatMatcher{name: "hex", desc: "a hex string", parse: func(s string) error {
+		_, err := hex.DecodeString(s)
+		return err
+	}}
+}
+
+var iso8601Layouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"20060102T150405Z0700",
+	"20060102",
+}
+
+// BeISO8601 matches ISO 8601 dates and date times in their extended and
+// basic formats.
+func BeISO8601() *formatMatcher {
+	return &formatMatcher{name: "iso8601", desc: "an ISO 8601 time", parse: func(s string) error {
+		var first error
+		for _, layout := range iso8601Layouts {
+			_, err := time.Parse(layout, s)
+			if err == nil {
+				return nil
+			}
+			if first == nil {
+				first = err
+			}
+		}
+
+		return first
+	}}
+}
+
+// BeMACAddress matches MAC addresses in any form net.ParseMAC accepts.
+func BeMACAddress() *formatMatcher {
+	return &formatMatcher{name: "mac", desc: "a MAC address", parse: func(s string) error {
+		_, err := net.ParseMAC(s)
+		return err
+	}}
+}
@@ -2,6 +2,8 @@ package matcher
 
 import (
 	"reflect"
+	"sort"
+	"sync"
 
 	"github.com/version-1/go-matcha/matcher/structs"
 )
@@ -14,17 +16,16 @@ type anyStruct struct {
 	options MatcherOptions
 }
 
-func (a anyStruct) Match(v any) bool {
-	if v == nil {
-		return false
-	}
+func (a anyStruct) Title() string {
+	return "StructMatcher got errors."
+}
 
-	if !a.options.AllowZero && isZero(v) {
-		return false
-	}
+func (a anyStruct) Match(v any) bool {
+	return len(a.Explain(v)) == 0
+}
 
-	s := MayStruct(v)
-	return s.IsStruct()
+func (a anyStruct) Explain(v any) []Record {
+	return explainKind(a, "struct", v, v != nil && MayStruct(v).IsStruct(), a.options)
 }
 
 func (a anyStruct) Not() Matcher {
@@ -40,8 +41,21 @@ func (a anyStruct) AllowZero() Matcher {
 	return a
 }
 
+func (a anyStruct) String() string {
+	return modifierString("struct", a.options)
+}
+
+func (a anyStruct) Describe() string {
+	return describeKind("struct", a.options)
+}
+
 type StructMap map[string]any
 
+// Row is a record of tabular data, such as a CSV record or a database row,
+// keyed by column name. StructOf matches rows like structs whose fields are
+// the columns, other maps are not structs to StructOf.
+type Row map[string]any
+
 func StructOf(fields StructMap, opts ...func(m *structs.MatcherOptions)) Matcher {
 	o := structs.MatcherOptions{}
 
@@ -55,18 +69,24 @@ func StructOf(fields StructMap, opts ...func(m *structs.MatcherOptions)) Matcher
 type structOfMatcher struct {
 	fields  StructMap
 	options structs.MatcherOptions
-	records []Record
+	result
 }
 
 func (m structOfMatcher) Title() string {
 	return "StructOfMatcher got errors."
 }
 
-func (m structOfMatcher) Records() []Record {
-	return m.records
+func (m *structOfMatcher) Match(v any) bool {
+	return m.match(v, m.options.Coercion)
 }
 
-func (m *structOfMatcher) Match(v any) bool {
+func (m *structOfMatcher) matchCoerced(v any) bool {
+	return m.match(v, true)
+}
+
+func (m *structOfMatcher) match(v any, coerce bool) bool {
+	m.records = nil
+
 	if v == nil {
 		r := recordTargetIsNil(m, v)
 		m.records = append(m.records, r)
@@ -74,29 +94,41 @@ func (m *structOfMatcher) Match(v any) bool {
 	}
 
 	s := MayStruct(v)
-	if !s.IsStruct() {
+	if !s.IsObject() {
 		r := recordUnexpectedType(m, "Struct", v)
 		m.records = append(m.records, r)
 		return false
 	}
 
-	fields := exportedFields(*s.t)
+	fields := s.Fields()
+	unmentioned := m.unmentionedFields(fields)
 	if !m.options.Contains && len(m.fields) != len(fields) {
 		r := recordUnmatchLength(m, len(m.fields), len(fields))
+		r.SetChildren(m.fieldRecords(s, fields, unmentioned))
 		m.records = append(m.records, r)
 		return false
 	}
 
+	if !m.options.Contains {
+		m.records = append(m.records, m.unmentionedRecords(s, unmentioned)...)
+	}
+
 	for k, v := range m.fields {
-		f := s.v.FieldByName(k)
-		if !f.IsValid() {
-			r := recordNotFound(m, k)
+		f, ok := s.Field(k)
+		if !ok {
+			r := recordNotFound(m, s.Segment(k))
+			r.Suggestions = m.suggest(k, fields, unmentioned)
 			m.records = append(m.records, r)
 			continue
 		}
 
-		if !Equal(v, f.Interface()) {
-			r := recordNotEqual(m, k, v, f.Interface())
+		actual, unwrapped := f.Interface(), false
+		if m.options.UnwrapValuers {
+			actual, unwrapped = unwrapValuer(v, actual)
+		}
+
+		if !equal(v, actual, coerce || unwrapped) {
+			r := recordNotEqual(m, s.Segment(k), v, f.Interface())
 			m.records = append(m.records, r)
 
 			continue
@@ -106,6 +138,73 @@ func (m *structOfMatcher) Match(v any) bool {
 	return len(m.records) == 0
 }
 
+// unmentionedFields returns the exported fields of the target that the
+// expectation has no key for.
+func (m *structOfMatcher) unmentionedFields(fields []reflect.StructField) []string {
+	if m.options.Contains {
+		return nil
+	}
+
+	res := []string{}
+	for _, f := range fields {
+		if _, ok := m.fields[f.Name]; !ok {
+			res = append(res, f.Name)
+		}
+	}
+
+	return res
+}
+
+func (m *structOfMatcher) unmentionedRecords(s mayStruct, unmentioned []string) []Record {
+	res := []Record{}
+	for _, name := range unmentioned {
+		var actual any
+		if f, ok := s.Field(name); ok {
+			actual = f.Interface()
+		}
+		res = append(res, recordUnexpectedField(m, s.Segment(name), actual))
+	}
+
+	return res
+}
+
+// fieldRecords explains a field count mismatch with the expectation keys
+// that do not exist on the target and the target fields that are not
+// mentioned.
+func (m *structOfMatcher) fieldRecords(s mayStruct, fields []reflect.StructField, unmentioned []string) []Record {
+	keys := make([]string, 0, len(m.fields))
+	for k := range m.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := []Record{}
+	for _, k := range keys {
+		if _, ok := s.Field(k); ok {
+			continue
+		}
+
+		r := recordNotFound(m, s.Segment(k))
+		r.Suggestions = m.suggest(k, fields, unmentioned)
+		res = append(res, r)
+	}
+
+	return append(res, m.unmentionedRecords(s, unmentioned)...)
+}
+
+// suggest returns the field names closest to a key that does not exist,
+// preferring the fields the expectation does not mention yet.
+func (m *structOfMatcher) suggest(key string, fields []reflect.StructField, unmentioned []string) []string {
+	candidates := unmentioned
+	if len(candidates) == 0 {
+		for _, f := range fields {
+			candidates = append(candidates, f.Name)
+		}
+	}
+
+	return suggestNames(key, candidates)
+}
+
 func (m structOfMatcher) Not() Matcher {
 	return Not(&m)
 }
@@ -114,34 +213,118 @@ func (m structOfMatcher) Pointer() Matcher {
 	return Ref(&m)
 }
 
-func exportedFields(t reflect.Type) []reflect.StructField {
-	fields := reflect.VisibleFields(t)
-	var res []reflect.StructField
-	for _, f := range fields {
+func (m structOfMatcher) Describe() string {
+	if m.options.Contains {
+		return "a struct containing " + describeFields(m.fields)
+	}
+
+	return "a struct of " + describeFields(m.fields)
+}
+
+type structInfo struct {
+	fields []reflect.StructField
+	byName map[string]reflect.StructField
+}
+
+var structInfoCache sync.Map // map[reflect.Type]*structInfo
+
+func cachedStructInfo(t reflect.Type) *structInfo {
+	if v, ok := structInfoCache.Load(t); ok {
+		return v.(*structInfo)
+	}
+
+	info := &structInfo{byName: map[string]reflect.StructField{}}
+	for _, f := range reflect.VisibleFields(t) {
 		if f.IsExported() {
-			res = append(res, f)
+			info.fields = append(info.fields, f)
+			info.byName[f.Name] = f
 		}
 	}
 
-	return res
+	v, _ := structInfoCache.LoadOrStore(t, info)
+	return v.(*structInfo)
+}
+
+func exportedFields(t reflect.Type) []reflect.StructField {
+	return cachedStructInfo(t).fields
 }
 
-func MayStruct(raw any) *mayStruct {
-	v := reflect.ValueOf(raw)
-	t := v.Type()
-	return &mayStruct{raw: raw, v: &v, t: &t}
+func MayStruct(raw any) mayStruct {
+	return mayStruct{raw: raw, v: reflect.ValueOf(raw)}
 }
 
 type mayStruct struct {
 	raw any
-	t   *reflect.Type
-	v   *reflect.Value
+	v   reflect.Value
 }
 
 func (m mayStruct) IsStruct() bool {
-	if m.t == nil {
-		return false
+	return m.v.Kind() == reflect.Struct
+}
+
+// IsObject reports whether the value is a struct or a Row, whose columns
+// StructOf treats as fields.
+func (m mayStruct) IsObject() bool {
+	return m.IsStruct() || m.isRow()
+}
+
+func (m mayStruct) isRow() bool {
+	_, ok := m.raw.(Row)
+	return ok
+}
+
+// Fields returns the exported fields of a struct, or the columns of a Row
+// as fields sorted by name.
+func (m mayStruct) Fields() []reflect.StructField {
+	row, ok := m.raw.(Row)
+	if !ok {
+		return exportedFields(m.v.Type())
 	}
 
-	return m.v.Kind() == reflect.Struct
+	res := make([]reflect.StructField, 0, len(row))
+	for k := range row {
+		res = append(res, reflect.StructField{Name: k})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+
+	return res
+}
+
+// Field returns the exported field named name, including promoted fields,
+// or the column name of a Row.
+func (m mayStruct) Field(name string) (reflect.Value, bool) {
+	if row, ok := m.raw.(Row); ok {
+		v, found := row[name]
+		if !found {
+			return reflect.Value{}, false
+		}
+		return reflect.ValueOf(&v).Elem(), true
+	}
+
+	sf, ok := cachedStructInfo(m.v.Type()).byName[name]
+	if !ok {
+		return reflect.Value{}, false
+	}
+
+	f, err := m.v.FieldByIndexErr(sf.Index)
+	if err != nil {
+		return reflect.Value{}, false
+	}
+
+	return f, true
+}
+
+// Segment returns the path segment of the field named name, with its JSON
+// name when the field is tagged.
+func (m mayStruct) Segment(name string) PathSegment {
+	if m.isRow() {
+		return MapKeySegment(name)
+	}
+
+	sf, ok := cachedStructInfo(m.v.Type()).byName[name]
+	if !ok {
+		return FieldSegment(name, "")
+	}
+
+	return structFieldSegment(sf)
 }
@@ -1,7 +1,9 @@
 package structs
 
 type MatcherOptions struct {
-	Contains bool
+	Contains      bool
+	Coercion      bool
+	UnwrapValuers bool
 }
 
 func WithContains(b bool) func(*MatcherOptions) {
@@ -9,3 +11,21 @@ func WithContains(b bool) func(*MatcherOptions) {
 		o.Contains = b
 	}
 }
+
+// WithCoercion compares fields like matcher.EqualLoose.
+func WithCoercion() func(*MatcherOptions) {
+	return func(o *MatcherOptions) {
+		o.Coercion = true
+	}
+}
+
+// WithUnwrapValuers compares plain field expectations with the value of
+// driver.Valuer fields like matcher.EqualLoose, e.g. "Alice" with
+// sql.NullString{String: "Alice", Valid: true}, 30 with sql.NullInt64{Int64:
+// 30, Valid: true} and nil with an invalid one. Matchers still get the
+// field as is.
+func WithUnwrapValuers() func(*MatcherOptions) {
+	return func(o *MatcherOptions) {
+		o.UnwrapValuers = true
+	}
+}
@@ -1,6 +1,12 @@
 package matcher
 
-import "time"
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
 
 func BeTime() *anyTime {
 	return &anyTime{}
@@ -10,14 +16,18 @@ type anyTime struct {
 	options MatcherOptions
 }
 
+func (m anyTime) Title() string {
+	return "TimeMatcher got errors."
+}
+
 var _ Matcher = anyTime{}
 
 func (m anyTime) Match(v any) bool {
-	if !m.options.AllowZero && isZero(v) {
-		return false
-	}
+	return len(m.Explain(v)) == 0
+}
 
-	return typeMatch[time.Time](v)
+func (m anyTime) Explain(v any) []Record {
+	return explainKind(m, "time.Time", v, typeMatch[time.Time](v), m.options)
 }
 
 func (m anyTime) Not() Matcher {
@@ -32,3 +42,204 @@ func (m anyTime) AllowZero() Matcher {
 	m.options.AllowZero = true
 	return m
 }
+
+func (m anyTime) String() string {
+	return modifierString("time", m.options)
+}
+
+func (m anyTime) Describe() string {
+	return describeKind("time", m.options)
+}
+
+// BeTimeWithin matches times at most d away from now, e.g. the creation
+// time of a record made by the test.
+func BeTimeWithin(d time.Duration) *timeCondMatcher {
+	return &timeCondMatcher{
+		name: fmt.Sprintf("time(within %s)", d),
+		desc: fmt.Sprintf("a time within %s of now", d),
+		check: func(t time.Time) bool {
+			diff := time.Since(t)
+			return -d <= diff && diff <= d
+		},
+	}
+}
+
+// BeTimeAfter matches times strictly after t.
+func BeTimeAfter(t time.Time) *timeCondMatcher {
+	return &timeCondMatcher{
+		name:  fmt.Sprintf("time(after %s)", t.Format(time.RFC3339Nano)),
+		desc:  fmt.Sprintf("a time after %s", t.Format(time.RFC3339Nano)),
+		check: func(v time.Time) bool { return v.After(t) },
+	}
+}
+
+// BeTimeBefore matches times strictly before t.
+func BeTimeBefore(t time.Time) *timeCondMatcher {
+	return &timeCondMatcher{
+		name:  fmt.Sprintf("time(before %s)", t.Format(time.RFC3339Nano)),
+		desc:  fmt.Sprintf("a time before %s", t.Format(time.RFC3339Nano)),
+		check: func(v time.Time) bool { return v.Before(t) },
+	}
+}
+
+type timeCondMatcher struct {
+	name  string
+	desc  string
+	check func(time.Time) bool
+}
+
+func (m timeCondMatcher) Title() string {
+	return "TimeMatcher got errors."
+}
+
+func (m timeCondMatcher) Match(v any) bool {
+	return len(m.Explain(v)) == 0
+}
+
+func (m timeCondMatcher) Explain(v any) []Record {
+	t, ok := v.(time.Time)
+	if !ok {
+		return []Record{recordUnexpectedType(m, "time.Time", v)}
+	}
+
+	if !m.check(t) {
+		return []Record{recordMismatch(m, t)}
+	}
+
+	return nil
+}
+
+func (m timeCondMatcher) Not() Matcher {
+	return Not(m)
+}
+
+func (m timeCondMatcher) Pointer() Matcher {
+	return Ref(m)
+}
+
+func (m timeCondMatcher) String() string {
+	return m.name
+}
+
+func (m timeCondMatcher) Describe() string {
+	return m.desc
+}
+
+// Layouts for BeTimeString. LayoutUnix and LayoutUnixMilli are not time
+// layouts but read seconds or milliseconds since the Unix epoch from
+// numbers or numeric strings.
+const (
+	LayoutRFC3339     = time.RFC3339
+	LayoutRFC3339Nano = time.RFC3339Nano
+	LayoutRFC1123     = time.RFC1123
+	LayoutUnix        = "unix"
+	LayoutUnixMilli   = "unixmilli"
+)
+
+// BeTimeString parses strings with layout and matches the resulting time
+// against inner, e.g. BeTimeString(LayoutRFC3339, BeTimeWithin(time.Minute)).
+// A nil inner only checks that the value parses.
+func BeTimeString(layout string, inner Matcher) *timeStringMatcher {
+	return &timeStringMatcher{layout: layout, inner: inner}
+}
+
+type timeStringMatcher struct {
+	layout string
+	inner  Matcher
+}
+
+func (m timeStringMatcher) Title() string {
+	return "TimeStringMatcher got errors."
+}
+
+func (m timeStringMatcher) Match(v any) bool {
+	return len(m.Explain(v)) == 0
+}
+
+func (m timeStringMatcher) Explain(v any) []Record {
+	t, err := m.parse(v)
+	if err != nil {
+		return []Record{recordParseError(m, m.layout, v, err)}
+	}
+
+	if m.inner != nil && !m.inner.Match(t) {
+		return []Record{recordNotEqual(m, PathSegment{}, m.inner, t)}
+	}
+
+	return nil
+}
+
+func (m timeStringMatcher) parse(v any) (time.Time, error) {
+	if m.layout != LayoutUnix && m.layout != LayoutUnixMilli {
+		s, ok := stringValue(v)
+		if !ok {
+			return time.Time{}, fmt.Errorf("expect a string but got %T", v)
+		}
+
+		return time.Parse(m.layout, s)
+	}
+
+	n, err := unixNumber(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if m.layout == LayoutUnixMilli {
+		return time.UnixMilli(n), nil
+	}
+
+	return time.Unix(n, 0), nil
+}
+
+// unixNumber reads an integer from integer kinds, integral floats,
+// json.Number or numeric strings.
+func unixNumber(v any) (int64, error) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case isIntKind(rv.Kind()):
+		return rv.Int(), nil
+	case isUintKind(rv.Kind()):
+		if rv.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("%d overflows int64", rv.Uint())
+		}
+		return int64(rv.Uint()), nil
+	case rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64:
+		f := rv.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, fmt.Errorf("%v is not an integer", f)
+		}
+		return int64(f), nil
+	}
+
+	s, ok := stringValue(v)
+	if !ok {
+		return 0, fmt.Errorf("expect a number but got %T", v)
+	}
+
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func (m timeStringMatcher) Not() Matcher {
+	return Not(m)
+}
+
+func (m timeStringMatcher) Pointer() Matcher {
+	return Ref(m)
+}
+
+func (m timeStringMatcher) String() string {
+	if m.inner == nil {
+		return fmt.Sprintf("timestring(%q)", m.layout)
+	}
+
+	return fmt.Sprintf("timestring(%q, %v)", m.layout, m.inner)
+}
+
+func (m timeStringMatcher) Describe() string {
+	s := fmt.Sprintf("a time string in layout %s", m.layout)
+	if m.inner != nil {
+		s += " that is " + Describe(m.inner)
+	}
+
+	return s
+}
@@ -0,0 +1,123 @@
+package matcher
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// BeOfType matches values whose dynamic type is exactly T. Zero values match
+// as well since only the type is checked.
+func BeOfType[T any]() *typeMatcher {
+	t := reflect.TypeFor[T]()
+	return &typeMatcher{
+		name:   fmt.Sprintf("type(%s)", typeName(t)),
+		expect: typeName(t),
+		desc:   fmt.Sprintf("a value of type %s", typeName(t)),
+		check:  func(vt reflect.Type) bool { return vt == t },
+	}
+}
+
+// Implement matches values whose dynamic type implements the interface I. It
+// panics when I is not an interface type.
+func Implement[I any]() *typeMatcher {
+	t := reflect.TypeFor[I]()
+	if t.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("matcher: Implement requires an interface type, got %s", typeName(t)))
+	}
+
+	return &typeMatcher{
+		name:   fmt.Sprintf("implement(%s)", typeName(t)),
+		expect: fmt.Sprintf("implementation of %s", typeName(t)),
+		desc:   fmt.Sprintf("an implementation of %s", typeName(t)),
+		check:  func(vt reflect.Type) bool { return vt.Implements(t) },
+	}
+}
+
+// BeKind matches values whose dynamic type is of kind k.
+func BeKind(k reflect.Kind) *typeMatcher {
+	return &typeMatcher{
+		name:   fmt.Sprintf("kind(%s)", k),
+		expect: fmt.Sprintf("kind %s", k),
+		desc:   fmt.Sprintf("a value of kind %s", k),
+		check:  func(vt reflect.Type) bool { return vt.Kind() == k },
+	}
+}
+
+// BeAssignableTo matches values whose dynamic type is assignable to T.
+func BeAssignableTo[T any]() *typeMatcher {
+	t := reflect.TypeFor[T]()
+	return &typeMatcher{
+		name:   fmt.Sprintf("assignable(%s)", typeName(t)),
+		expect: fmt.Sprintf("assignable to %s", typeName(t)),
+		desc:   fmt.Sprintf("a value assignable to %s", typeName(t)),
+		check:  func(vt reflect.Type) bool { return vt.AssignableTo(t) },
+	}
+}
+
+type typeMatcher struct {
+	name   string
+	expect string
+	desc   string
+	check  func(reflect.Type) bool
+	result
+}
+
+func (m typeMatcher) Title() string {
+	return "TypeMatcher got errors."
+}
+
+func (m *typeMatcher) Match(v any) bool {
+	m.records = nil
+
+	vt := reflect.TypeOf(v)
+	if vt == nil || !m.check(vt) {
+		m.records = append(m.records, recordUnexpectedType(m, m.expect, v))
+		return false
+	}
+
+	return true
+}
+
+func (m typeMatcher) Not() Matcher {
+	return Not(&m)
+}
+
+func (m typeMatcher) Pointer() Matcher {
+	return Ref(&m)
+}
+
+func (m typeMatcher) String() string {
+	return m.name
+}
+
+func (m typeMatcher) Describe() string {
+	return m.desc
+}
+
+// typeName is the name of t qualified with full package paths, e.g.
+// *github.com/google/uuid.UUID instead of *uuid.UUID.
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "nil"
+	}
+
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name()
+		}
+		return t.PkgPath() + "." + t.Name()
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + typeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + typeName(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), typeName(t.Elem()))
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", typeName(t.Key()), typeName(t.Elem()))
+	default:
+		return t.String()
+	}
+}
@@ -0,0 +1,21 @@
+package urls
+
+type MatcherOptions struct {
+	Schemes []string
+	Hosts   []string
+}
+
+// WithSchemes allows only the given schemes, e.g. "https".
+func WithSchemes(schemes ...string) func(*MatcherOptions) {
+	return func(o *MatcherOptions) {
+		o.Schemes = schemes
+	}
+}
+
+// WithHosts allows only the given host names. A leading "*." matches any
+// subdomain, e.g. "*.example.com".
+func WithHosts(hosts ...string) func(*MatcherOptions) {
+	return func(o *MatcherOptions) {
+		o.Hosts = hosts
+	}
+}
@@ -2,6 +2,9 @@ package matcher
 
 import (
 	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
 )
 
 func typeMatch[T any](v any) bool {
@@ -13,21 +16,131 @@ func typeMatch[T any](v any) bool {
 	}
 }
 
-func isZero(v any) bool {
-	if v == nil {
+var allowNamed atomic.Bool
+
+// SetAllowNamed makes BeString, BeInt and BeBool match named types by their
+// underlying kind in every assertion, as if AllowNamed was called on each,
+// and returns the previous setting.
+func SetAllowNamed(allow bool) bool {
+	return allowNamed.Swap(allow)
+}
+
+// kindMatch is typeMatch that also accepts named types of T's kind when
+// o.AllowNamed or the global default is set.
+func kindMatch[T any](v any, o MatcherOptions) bool {
+	if typeMatch[T](v) {
 		return true
 	}
 
-	vv := reflect.ValueOf(v)
+	if !o.AllowNamed && !allowNamed.Load() {
+		return false
+	}
+
+	t := reflect.TypeOf(v)
+	return t != nil && t.Kind() == reflect.TypeFor[T]().Kind()
+}
 
-	if !vv.IsValid() {
+// stringValue returns the text of a string, a non-nil *string or a value
+// of a named string type.
+func stringValue(v any) (string, bool) {
+	switch vv := v.(type) {
+	case string:
+		return vv, true
+	case *string:
+		if vv == nil {
+			return "", false
+		}
+		return *vv, true
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.String {
+		return "", false
+	}
+
+	return rv.String(), true
+}
+
+// bytesValue returns the content of a []byte or of a value stringValue
+// accepts.
+func bytesValue(v any) ([]byte, bool) {
+	if b, ok := v.([]byte); ok {
+		return b, true
+	}
+
+	s, ok := stringValue(v)
+	return []byte(s), ok
+}
+
+func isZero(v any) bool {
+	switch vv := v.(type) {
+	case nil:
 		return true
+	case string:
+		return vv == ""
+	case int:
+		return vv == 0
+	case bool:
+		return !vv
 	}
 
-	vt := vv.Type()
-	if isSlice(vt) {
+	vv := reflect.ValueOf(v)
+	if isSliceKind(vv.Kind()) {
 		return vv.Len() == 0
 	}
 
-	return v == reflect.Zero(vt).Interface()
+	return vv.IsZero()
+}
+
+// suggestNames returns the candidates closest to name by edit distance,
+// ignoring case, or nothing when none of them is close enough to be a typo.
+func suggestNames(name string, candidates []string) []string {
+	limit := max(1, len(name)/3)
+
+	best := limit + 1
+	res := []string{}
+	for _, c := range candidates {
+		d := editDistance(strings.ToLower(name), strings.ToLower(c))
+		switch {
+		case d < best:
+			best = d
+			res = []string{c}
+		case d == best:
+			res = append(res, c)
+		}
+	}
+
+	sort.Strings(res)
+	return res
+}
+
+// editDistance is the optimal string alignment distance between a and b,
+// which counts swapping two adjacent characters as a single edit.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(ra)][len(rb)]
 }
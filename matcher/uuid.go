@@ -1,23 +1,133 @@
 package matcher
 
-import "github.com/google/uuid"
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 func BeUUID() *anyUUID {
 	return &anyUUID{}
 }
 
+// BeUUIDString matches the text forms of a UUID, canonical, braced or URN,
+// given as string, *string or []byte, and the 16 raw bytes of one.
+func BeUUIDString() *anyUUID {
+	return &anyUUID{text: true}
+}
+
 type anyUUID struct {
-	options MatcherOptions
+	options  MatcherOptions
+	text     bool
+	versions []uuid.Version
+	variants []uuid.Variant
+	within   time.Duration
+}
+
+func (m anyUUID) Title() string {
+	return "UUIDMatcher got errors."
 }
 
 var _ Matcher = anyUUID{}
 
 func (m anyUUID) Match(v any) bool {
+	return len(m.Explain(v)) == 0
+}
+
+func (m anyUUID) Explain(v any) []Record {
+	if v == nil {
+		return []Record{recordTargetIsNil(m, v)}
+	}
+
 	if !m.options.AllowZero && (uuid.Nil == v || isZero(v)) {
-		return false
+		return []Record{recordZeroValue(m, v)}
+	}
+
+	u, ok, err := m.uuid(v)
+	if !ok {
+		return []Record{recordUnexpectedType(m, m.kind(), v)}
+	}
+
+	if err != nil {
+		return []Record{recordParseError(m, "uuid", v, err)}
+	}
+
+	if !m.options.AllowZero && u == uuid.Nil {
+		return []Record{recordZeroValue(m, v)}
 	}
 
-	return typeMatch[uuid.UUID](v)
+	if len(m.versions) > 0 && !slices.Contains(m.versions, u.Version()) {
+		return []Record{recordNotAllowed(m, m.versions, u.Version())}
+	}
+
+	if len(m.variants) > 0 && !slices.Contains(m.variants, u.Variant()) {
+		return []Record{recordNotAllowed(m, m.variants, u.Variant())}
+	}
+
+	if m.within > 0 {
+		created, ok := uuidTime(u)
+		if !ok {
+			return []Record{recordNotAllowed(m, []uuid.Version{1, 2, 6, 7}, u.Version())}
+		}
+
+		if within := BeTimeWithin(m.within); !within.Match(created) {
+			return []Record{recordNotEqual(m, PathSegment{}, within, created)}
+		}
+	}
+
+	return nil
+}
+
+// kind is the type of values m parses.
+func (m anyUUID) kind() string {
+	if m.text {
+		return "string"
+	}
+
+	return "uuid.UUID"
+}
+
+// uuid returns the UUID in v, whether v is of a type m accepts and the
+// error parsing it.
+func (m anyUUID) uuid(v any) (uuid.UUID, bool, error) {
+	if !m.text {
+		u, ok := v.(uuid.UUID)
+		return u, ok, nil
+	}
+
+	var u uuid.UUID
+	var err error
+	switch vv := v.(type) {
+	case []byte:
+		if len(vv) == 16 {
+			u, err = uuid.FromBytes(vv)
+		} else {
+			u, err = uuid.ParseBytes(vv)
+		}
+	default:
+		s, ok := stringValue(v)
+		if !ok {
+			return uuid.Nil, false, nil
+		}
+		u, err = uuid.Parse(s)
+	}
+
+	return u, true, err
+}
+
+// uuidTime is the creation time embedded in time based UUIDs.
+func uuidTime(u uuid.UUID) (time.Time, bool) {
+	switch u.Version() {
+	case 1, 2, 6, 7:
+		sec, nsec := u.Time().UnixTime()
+		return time.Unix(sec, nsec), true
+	default:
+		return time.Time{}, false
+	}
 }
 
 func (m anyUUID) Not() Matcher {
@@ -32,3 +142,82 @@ func (m anyUUID) AllowZero() Matcher {
 	m.options.AllowZero = true
 	return m
 }
+
+// Version restricts the UUID to one of versions, e.g. Version(4, 7).
+func (m anyUUID) Version(versions ...uuid.Version) *anyUUID {
+	m.versions = versions
+	return &m
+}
+
+// Variant restricts the UUID to one of variants, e.g. uuid.RFC4122.
+func (m anyUUID) Variant(variants ...uuid.Variant) *anyUUID {
+	m.variants = variants
+	return &m
+}
+
+// CreatedWithin requires a time based UUID, such as v7, whose embedded
+// timestamp is at most d away from now.
+func (m anyUUID) CreatedWithin(d time.Duration) *anyUUID {
+	m.within = d
+	return &m
+}
+
+func (m anyUUID) String() string {
+	name := "uuid"
+	if m.text {
+		name = "uuidstring"
+	}
+
+	conds := []string{}
+	if len(m.versions) > 0 {
+		s := make([]string, len(m.versions))
+		for i, v := range m.versions {
+			s[i] = strconv.Itoa(int(v))
+		}
+		conds = append(conds, "version "+strings.Join(s, "|"))
+	}
+	if len(m.variants) > 0 {
+		s := make([]string, len(m.variants))
+		for i, v := range m.variants {
+			s[i] = v.String()
+		}
+		conds = append(conds, "variant "+strings.Join(s, "|"))
+	}
+	if m.within > 0 {
+		conds = append(conds, fmt.Sprintf("created within %s", m.within))
+	}
+
+	if len(conds) > 0 {
+		name = fmt.Sprintf("%s(%s)", name, strings.Join(conds, ", "))
+	}
+
+	return modifierString(name, m.options)
+}
+
+func (m anyUUID) Describe() string {
+	name := "UUID"
+	if m.text {
+		name = "UUID string"
+	}
+
+	conds := []string{}
+	if len(m.versions) > 0 {
+		s := make([]string, len(m.versions))
+		for i, v := range m.versions {
+			s[i] = strconv.Itoa(int(v))
+		}
+		conds = append(conds, "of version "+strings.Join(s, " or "))
+	}
+	if len(m.variants) > 0 {
+		s := make([]string, len(m.variants))
+		for i, v := range m.variants {
+			s[i] = v.String()
+		}
+		conds = append(conds, "of variant "+strings.Join(s, " or "))
+	}
+	if m.within > 0 {
+		conds = append(conds, fmt.Sprintf("created within %s", m.within))
+	}
+
+	return strings.Join(append([]string{describeKind(name, m.options)}, conds...), " ")
+}
@@ -0,0 +1,337 @@
+package matcher
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// XMLNode is an expected XML element. Attributes not listed in Attrs are
+// ignored, a nil Text or Children is not checked. Attribute values and
+// Text may be matchers.
+type XMLNode struct {
+	Name     string
+	Attrs    map[string]any
+	Text     any
+	Children []XMLNode
+}
+
+func (n XMLNode) childrenNamed(name string) []XMLNode {
+	list := []XMLNode{}
+	for _, c := range n.Children {
+		if c.Name == name {
+			list = append(list, c)
+		}
+	}
+
+	return list
+}
+
+// String renders the expected start tag, e.g. <item sku="A-1">.
+func (n XMLNode) String() string {
+	keys := make([]string, 0, len(n.Attrs))
+	for k := range n.Attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("<" + n.Name)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%s", k, argString(n.Attrs[k]))
+	}
+	b.WriteString(">")
+
+	return b.String()
+}
+
+// XMLOf matches XML documents, given as string, *string or []byte, whose
+// root element matches expect. Records nest like the document: an element
+// that didn't match has the records of its attributes, keyed "@name", its
+// text, keyed text(), and its children, keyed by name and, when the parent
+// has several children of that name, by their index among them. Elements
+// whose name and attributes matched are RecordCodeNested records, which
+// render only the records below them.
+func XMLOf(expect XMLNode) Matcher {
+	return &xmlMatcher{expect: expect}
+}
+
+type xmlMatcher struct {
+	expect XMLNode
+	result
+}
+
+func (m xmlMatcher) Title() string {
+	return "XMLMatcher got errors."
+}
+
+func (m *xmlMatcher) Match(v any) bool {
+	m.records = nil
+
+	b, ok := bytesValue(v)
+	if !ok {
+		m.records = append(m.records, recordUnexpectedType(m, "string", v))
+		return false
+	}
+
+	root, err := decodeXML(b)
+	if err != nil {
+		m.records = append(m.records, recordParseError(m, "xml", v, err))
+		return false
+	}
+
+	if r, ok := m.matchElement(FieldSegment(root.name, ""), m.expect, root); !ok {
+		m.records = append(m.records, r)
+	}
+
+	return len(m.records) == 0
+}
+
+// matchElement returns the record of e under seg when it doesn't match. An
+// element whose name and attributes matched only groups the records of its
+// text and children.
+func (m *xmlMatcher) matchElement(seg PathSegment, expect XMLNode, e *xmlElement) (Record, bool) {
+	if expect.Name != e.name {
+		return recordNotEqual(m, seg, expect.Name, e.name), false
+	}
+
+	attrs := m.attrRecords(expect, e)
+	rs := append(attrs, m.contentRecords(expect, e)...)
+	if len(rs) == 0 {
+		return Record{}, true
+	}
+
+	if len(attrs) == 0 {
+		return recordNested(m, seg, rs), false
+	}
+
+	r := recordNotEqual(m, seg, expect, e)
+	r.SetChildren(rs)
+	return r, false
+}
+
+func (m *xmlMatcher) attrRecords(expect XMLNode, e *xmlElement) []Record {
+	res := []Record{}
+
+	keys := make([]string, 0, len(expect.Attrs))
+	for k := range expect.Attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		seg := FieldSegment("@"+k, "")
+		a, ok := e.attrs[k]
+		if !ok {
+			res = append(res, recordNotFound(m, seg))
+			continue
+		}
+
+		if !Equal(expect.Attrs[k], a) {
+			res = append(res, recordNotEqual(m, seg, expect.Attrs[k], a))
+		}
+	}
+
+	return res
+}
+
+func (m *xmlMatcher) contentRecords(expect XMLNode, e *xmlElement) []Record {
+	res := []Record{}
+
+	if expect.Text != nil {
+		text := strings.TrimSpace(e.text)
+		if !Equal(expect.Text, text) {
+			res = append(res, recordNotEqual(m, MethodSegment("text"), expect.Text, text))
+		}
+	}
+
+	if expect.Children == nil {
+		return res
+	}
+
+	if len(expect.Children) != len(e.children) {
+		res = append(res, recordUnmatchLength(m, len(expect.Children), len(e.children)))
+	}
+
+	// children of a repeated name are nested below one record per name.
+	groups := map[string]int{}
+	for i, c := range expect.Children {
+		var r Record
+		var name string
+		var pos, count int
+		if i >= len(e.children) {
+			names := make([]string, len(expect.Children))
+			for j, cc := range expect.Children {
+				names[j] = cc.Name
+			}
+			name = c.Name
+			pos, count = stepOf(names, i)
+			r = recordNotFoundExpect(m, xmlStep(name, pos, count), c)
+		} else {
+			name = e.children[i].name
+			pos, count = e.step(i)
+			var ok bool
+			if r, ok = m.matchElement(xmlStep(name, pos, count), c, e.children[i]); ok {
+				continue
+			}
+		}
+
+		if count == 1 {
+			res = append(res, r)
+			continue
+		}
+
+		j, ok := groups[name]
+		if !ok {
+			j = len(res)
+			groups[name] = j
+			res = append(res, recordNested(m, FieldSegment(name, ""), nil))
+		}
+		// matchElement links the children with SetChildren.
+		res[j].Children = append(res[j].Children, r)
+	}
+
+	return res
+}
+
+// xmlStep is the segment of a child element of a name, or of its index
+// among count children of that name.
+func xmlStep(name string, pos, count int) PathSegment {
+	if count == 1 {
+		return FieldSegment(name, "")
+	}
+
+	return IndexSegment(pos)
+}
+
+// stepOf returns the 0-based position of names[i] among the equal names
+// and how many there are.
+func stepOf(names []string, i int) (int, int) {
+	pos, count := 0, 0
+	for j, name := range names {
+		if name != names[i] {
+			continue
+		}
+		if j < i {
+			pos++
+		}
+		count++
+	}
+
+	return pos, count
+}
+
+func (m xmlMatcher) Not() Matcher {
+	return Not(&m)
+}
+
+func (m xmlMatcher) Pointer() Matcher {
+	return Ref(&m)
+}
+
+func (m xmlMatcher) String() string {
+	return fmt.Sprintf("xml(%s)", m.expect.Name)
+}
+
+func (m xmlMatcher) Describe() string {
+	return fmt.Sprintf("an XML document with root element <%s>", m.expect.Name)
+}
+
+type xmlElement struct {
+	name     string
+	attrs    map[string]string
+	text     string
+	children []*xmlElement
+}
+
+// step returns the position of the i-th child among the children of its
+// name and how many there are.
+func (e *xmlElement) step(i int) (int, int) {
+	names := make([]string, len(e.children))
+	for j, c := range e.children {
+		names[j] = c.name
+	}
+
+	return stepOf(names, i)
+}
+
+func (e *xmlElement) childrenNamed(name string) []*xmlElement {
+	list := []*xmlElement{}
+	for _, c := range e.children {
+		if c.name == name {
+			list = append(list, c)
+		}
+	}
+
+	return list
+}
+
+// String renders the start tag of e.
+func (e *xmlElement) String() string {
+	keys := make([]string, 0, len(e.attrs))
+	for k := range e.attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("<" + e.name)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%q", k, e.attrs[k])
+	}
+	b.WriteString(">")
+
+	return b.String()
+}
+
+// decodeXML builds the element tree of a document by local names.
+func decodeXML(b []byte) (*xmlElement, error) {
+	d := xml.NewDecoder(bytes.NewReader(b))
+
+	var root *xmlElement
+	stack := []*xmlElement{}
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			e := &xmlElement{name: t.Name.Local, attrs: map[string]string{}}
+			for _, a := range t.Attr {
+				e.attrs[a.Name.Local] = a.Value
+			}
+
+			if len(stack) == 0 {
+				if root != nil {
+					return nil, errors.New("more than one root element")
+				}
+				root = e
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, e)
+			}
+			stack = append(stack, e)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("no root element")
+	}
+
+	return root, nil
+}
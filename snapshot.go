@@ -0,0 +1,388 @@
+package matcha
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/version-1/go-matcha/assert"
+	"github.com/version-1/go-matcha/matcher"
+)
+
+const (
+	snapshotDir    = "testdata/__snapshots__"
+	snapshotExt    = ".snap"
+	snapshotMasked = "<<masked>>"
+	snapshotEnvKey = "MATCHA_UPDATE"
+)
+
+type SnapshotTesting interface {
+	assert.Testing
+	Name() string
+}
+
+type SnapshotMask struct {
+	Path    string
+	Matcher matcher.Matcher
+}
+
+type SnapshotOptions struct {
+	Dir    string
+	Update bool
+	Masks  []SnapshotMask
+}
+
+// Mask replaces the value at path with a placeholder in the snapshot and
+// validates it with m instead. Path segments are separated by "." and "*"
+// matches any field, key or index (e.g. "Posts.*.ID").
+func Mask(path string, m matcher.Matcher) func(*SnapshotOptions) {
+	return func(o *SnapshotOptions) {
+		o.Masks = append(o.Masks, SnapshotMask{Path: path, Matcher: m})
+	}
+}
+
+func WithSnapshotDir(dir string) func(*SnapshotOptions) {
+	return func(o *SnapshotOptions) {
+		o.Dir = dir
+	}
+}
+
+func WithUpdate(v bool) func(*SnapshotOptions) {
+	return func(o *SnapshotOptions) {
+		o.Update = v
+	}
+}
+
+// Snapshot serializes value and compares it with the golden file
+// testdata/__snapshots__/<TestName>.snap. The file is written when it does
+// not exist yet, or when MATCHA_UPDATE=1 is set, WithUpdate(true) is passed
+// or the test binary defines an -update flag and it is given. See
+// RegisterUpdateFlag for the one-liner that defines it.
+func Snapshot(t SnapshotTesting, value any, opts ...func(*SnapshotOptions)) {
+	o := SnapshotOptions{
+		Dir:    snapshotDir,
+		Update: shouldUpdateSnapshots(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	s := &snapshot{
+		path:  filepath.Join(o.Dir, snapshotFileName(t.Name())),
+		masks: o.Masks,
+	}
+
+	actual := s.serialize(value)
+
+	expect, err := os.ReadFile(s.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("failed to read snapshot %s: %s", s.path, err)
+		t.FailNow()
+		return
+	}
+
+	if o.Update || errors.Is(err, fs.ErrNotExist) {
+		if err := s.save(actual); err != nil {
+			log.Printf("failed to write snapshot %s: %s", s.path, err)
+			t.FailNow()
+			return
+		}
+	} else {
+		s.compare(string(expect), actual)
+	}
+
+	if len(s.records) == 0 {
+		return
+	}
+
+	assert.New(t, s, value).Assert()
+}
+
+// RegisterUpdateFlag defines the -update flag that rewrites snapshots and
+// reports whether it did; it leaves a flag the test binary already defines
+// alone. Call it from a _test.go file of the package using Snapshot:
+//
+//	var _ = matcha.RegisterUpdateFlag()
+//
+// and run go test -update. matcha never calls it itself, so that test
+// packages are free to declare their own -update flag instead.
+func RegisterUpdateFlag() bool {
+	return registerUpdateFlag(flag.CommandLine)
+}
+
+func registerUpdateFlag(fs *flag.FlagSet) bool {
+	if fs.Lookup("update") != nil {
+		return false
+	}
+
+	fs.Bool("update", false, "rewrite matcha snapshot files")
+	return true
+}
+
+// shouldUpdateSnapshots reads the -update flag lazily, whether it was
+// defined by RegisterUpdateFlag or by the test package itself.
+func shouldUpdateSnapshots() bool {
+	if v, err := strconv.ParseBool(os.Getenv(snapshotEnvKey)); err == nil && v {
+		return true
+	}
+
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+
+	v, err := strconv.ParseBool(f.Value.String())
+	return err == nil && v
+}
+
+// snapshotFileReplacer turns the subtest separator into "_" and
+// percent-escapes "_" itself along with the other characters that can't
+// appear in a file name, so that distinct test names never share a file
+// (e.g. "a/b" and "a_b").
+var snapshotFileReplacer = strings.NewReplacer(
+	"%", "%25",
+	"_", "%5F",
+	"/", "_",
+	"\\", "%5C",
+	":", "%3A",
+)
+
+func snapshotFileName(name string) string {
+	return snapshotFileReplacer.Replace(name) + snapshotExt
+}
+
+type snapshot struct {
+	path    string
+	masks   []SnapshotMask
+	used    map[int]bool
+	records []matcher.Record
+}
+
+var _ matcher.Recorder = &snapshot{}
+
+func (s snapshot) Title() string {
+	return fmt.Sprintf("Snapshot ( %s ) got errors.", s.path)
+}
+
+func (s snapshot) Records() []matcher.Record {
+	return s.records
+}
+
+func (s *snapshot) save(content string) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, []byte(content), 0o644)
+}
+
+func (s *snapshot) compare(expect, actual string) {
+	if expect == actual {
+		return
+	}
+
+	el := strings.Split(expect, "\n")
+	al := strings.Split(actual, "\n")
+	n := max(len(el), len(al))
+	for i := 0; i < n; i++ {
+		var e, a string
+		if i < len(el) {
+			e = el[i]
+		}
+		if i < len(al) {
+			a = al[i]
+		}
+
+		if e != a {
+			s.records = append(s.records, matcher.Record{
+				Key:    fmt.Sprintf("line %d", i+1),
+				Expect: e,
+				Actual: a,
+				Code:   matcher.RecordCodeNotEqual,
+			})
+		}
+	}
+}
+
+func (s *snapshot) serialize(value any) string {
+	s.used = map[int]bool{}
+
+	var b strings.Builder
+	s.encode(&b, reflect.ValueOf(value), nil, 0)
+	b.WriteString("\n")
+
+	for i, m := range s.masks {
+		if !s.used[i] {
+			s.records = append(s.records, matcher.Record{
+				Key:  m.Path,
+				Code: matcher.RecordCodeNotFound,
+			})
+		}
+	}
+
+	return b.String()
+}
+
+func (s *snapshot) mask(path []string, v reflect.Value) bool {
+	masked := false
+	for i, m := range s.masks {
+		if !matchMaskPath(m.Path, path) {
+			continue
+		}
+
+		masked = true
+		s.used[i] = true
+
+		var actual any
+		if v.IsValid() && v.CanInterface() {
+			actual = v.Interface()
+		}
+
+		if !matcher.Equal(m.Matcher, actual) {
+			r := matcher.Record{
+				Matcher: m.Matcher,
+				Key:     strings.Join(path, "."),
+				Expect:  m.Matcher,
+				Actual:  actual,
+				Code:    matcher.RecordCodeNotEqual,
+			}
+			r.SetChildren(matcher.Explain(m.Matcher, actual))
+			s.records = append(s.records, r)
+		}
+	}
+
+	return masked
+}
+
+func matchMaskPath(mask string, path []string) bool {
+	segments := strings.Split(mask, ".")
+	if len(segments) != len(path) {
+		return false
+	}
+
+	for i := range segments {
+		if segments[i] != "*" && segments[i] != path[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+func (s *snapshot) encode(b *strings.Builder, v reflect.Value, path []string, depth int) {
+	if len(path) > 0 && s.mask(path, v) {
+		b.WriteString(snapshotMasked)
+		return
+	}
+
+	if !v.IsValid() {
+		b.WriteString("nil")
+		return
+	}
+
+	if v.Type().Implements(textMarshalerType) && v.CanInterface() {
+		if v.Kind() != reflect.Pointer || !v.IsNil() {
+			text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+			if err == nil {
+				fmt.Fprintf(b, "%s(%q)", v.Type(), text)
+				return
+			}
+		}
+	}
+
+	indent := strings.Repeat("\t", depth+1)
+	closing := strings.Repeat("\t", depth)
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			b.WriteString("nil")
+			return
+		}
+		b.WriteString("&")
+		s.encode(b, v.Elem(), path, depth)
+	case reflect.Interface:
+		if v.IsNil() {
+			b.WriteString("nil")
+			return
+		}
+		s.encode(b, v.Elem(), path, depth)
+	case reflect.Struct:
+		fmt.Fprintf(b, "%s{\n", v.Type())
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fmt.Fprintf(b, "%s%s: ", indent, f.Name)
+			s.encode(b, v.Field(i), append(path, f.Name), depth+1)
+			b.WriteString(",\n")
+		}
+		fmt.Fprintf(b, "%s}", closing)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			fmt.Fprintf(b, "%s(nil)", v.Type())
+			return
+		}
+		fmt.Fprintf(b, "%s{\n", v.Type())
+		for i := 0; i < v.Len(); i++ {
+			b.WriteString(indent)
+			s.encode(b, v.Index(i), append(path, strconv.Itoa(i)), depth+1)
+			b.WriteString(",\n")
+		}
+		fmt.Fprintf(b, "%s}", closing)
+	case reflect.Map:
+		if v.IsNil() {
+			fmt.Fprintf(b, "%s(nil)", v.Type())
+			return
+		}
+		keys := v.MapKeys()
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = fmt.Sprint(k.Interface())
+		}
+		sort.Sort(mapKeys{keys, names})
+
+		fmt.Fprintf(b, "%s{\n", v.Type())
+		for i, k := range keys {
+			fmt.Fprintf(b, "%s%#v: ", indent, k.Interface())
+			s.encode(b, v.MapIndex(k), append(path, names[i]), depth+1)
+			b.WriteString(",\n")
+		}
+		fmt.Fprintf(b, "%s}", closing)
+	case reflect.String:
+		fmt.Fprintf(b, "%q", v.String())
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		fmt.Fprintf(b, "%s", v.Type())
+	default:
+		if v.CanInterface() {
+			fmt.Fprintf(b, "%#v", v.Interface())
+			return
+		}
+		fmt.Fprintf(b, "%v", v)
+	}
+}
+
+type mapKeys struct {
+	keys  []reflect.Value
+	names []string
+}
+
+func (m mapKeys) Len() int           { return len(m.keys) }
+func (m mapKeys) Less(i, j int) bool { return m.names[i] < m.names[j] }
+func (m mapKeys) Swap(i, j int) {
+	m.keys[i], m.keys[j] = m.keys[j], m.keys[i]
+	m.names[i], m.names[j] = m.names[j], m.names[i]
+}